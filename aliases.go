@@ -0,0 +1,123 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// WithAliasPages makes GenTreeDocs generate a stub page for every alias of a documented command,
+// pointing to the page of the command. With WithRedirects, the alias paths are redirected to the page
+// of the command in the redirects file instead, so no stub page is generated.
+func WithAliasPages() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.aliasPages = true
+	}
+}
+
+// WithDeprecatedCommands documents deprecated commands, with a warning banner holding cmd.Deprecated,
+// instead of skipping them.
+func WithDeprecatedCommands() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.deprecatedCommands = true
+	}
+}
+
+// aliasPaths returns the command paths cmd can be invoked with using its aliases.
+func aliasPaths(cmd *cobra.Command) []string {
+	return siblingPaths(cmd, cmd.Aliases)
+}
+
+// siblingPaths returns the command paths of names under the parent of cmd.
+func siblingPaths(cmd *cobra.Command, names []string) []string {
+	if !cmd.HasParent() {
+		return nil
+	}
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, cmd.Parent().CommandPath()+" "+name)
+	}
+	return paths
+}
+
+// printAliases lists the alias paths of cmd and, so readers searching for them find the page,
+// the paths of cmd.SuggestFor for which cobra suggests cmd.
+func printAliases(buf *bytes.Buffer, cmd *cobra.Command) {
+	if paths := aliasPaths(cmd); len(paths) > 0 {
+		buf.WriteString("\nAliases: ``" + strings.Join(paths, "``, ``") + "``\n")
+	}
+	if paths := siblingPaths(cmd, cmd.SuggestFor); len(paths) > 0 {
+		buf.WriteString("\nAlso matches: ``" + strings.Join(paths, "``, ``") + "``\n")
+	}
+}
+
+func printDeprecationWarning(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) {
+	if cmd.Deprecated == "" {
+		return
+	}
+	buf.WriteString("\n.. warning::\n\n")
+	buf.WriteString(indentString("This command is deprecated. "+options.formatText(cmd, cmd.Deprecated), "   ") + "\n")
+}
+
+// GenAliasDocs creates the stub page of the alias path of cmd, pointing to the page of cmd.
+func GenAliasDocs(cmd *cobra.Command, path string, w io.Writer, genDocOptions ...GenDocsOption) error {
+	options := newGenDocsOptions(genDocOptions)
+	if options.err != nil {
+		return options.err
+	}
+	title := options.sanitizer.escapeLine(path+" alias path", path)
+	if err := options.sanitizer.err(); err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(".. _" + options.pageStyle.label(path) + ":\n\n")
+	buf.WriteString(options.pageStyle.title(title) + "\n")
+	_, _ = fmt.Fprintf(buf, "``%s`` is an alias of :ref:`%s <%s>`.\n\n", path, cmd.CommandPath(), options.label(cmd))
+	if !cmd.DisableAutoGenTag {
+		buf.WriteString(options.footer() + "\n")
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func genAliasFiles(cmd *cobra.Command, dir string, genDocOptions []GenDocsOption) error {
+	for _, path := range aliasPaths(cmd) {
+		basename := strings.ReplaceAll(path, " ", separator) + defaultExtension
+		if err := genFile(filepath.Join(dir, basename), func(w io.Writer) error {
+			return GenAliasDocs(cmd, path, w, genDocOptions...)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func genFile(filename string, gen func(w io.Writer) error) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return gen(f)
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestGenDocsAliases(t *testing.T) {
+	Root() // init root
+	buf := new(bytes.Buffer)
+	if err := GenDocs(Echo(), buf, WithoutDate()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringContains(t, buf.String(), "\nAliases: ``root say``\n")
+	checkStringOmits(t, buf.String(), "Also matches:")
+
+	buf.Reset()
+	root := &cobra.Command{Use: "mycli"}
+	repeat := &cobra.Command{Use: "repeat", SuggestFor: []string{"times", "again"}, Short: "Repeat", Run: emptyRun}
+	root.AddCommand(repeat)
+	if err := GenDocs(repeat, buf, WithoutDate()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringContains(t, buf.String(), "\nAlso matches: ``mycli times``, ``mycli again``\n")
+
+	buf.Reset()
+	if err := GenDocs(Root(), buf, WithoutDate()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringOmits(t, buf.String(), "Aliases:")
+}
+
+func TestGenTreeDocsAliasPages(t *testing.T) {
+	root := &cobra.Command{Use: "mycli"}
+	root.AddCommand(&cobra.Command{Use: "echo", Aliases: []string{"say", "print"}, Short: "Echo", Run: emptyRun})
+
+	dir := t.TempDir()
+	if err := GenTreeDocs(root, dir, WithoutDate()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "mycli-say.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected no alias page without WithAliasPages, got: %v", err)
+	}
+
+	if err := GenTreeDocs(root, dir, WithoutDate(), WithAliasPages()); err != nil {
+		t.Fatal(err)
+	}
+	for _, alias := range []string{"say", "print"} {
+		b, err := os.ReadFile(filepath.Join(dir, "mycli-"+alias+".txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := "mycli " + alias
+		adornment := strings.Repeat("=", len(path))
+		expected := ".. _mycli-" + alias + ":\n\n" +
+			adornment + "\n" + path + "\n" + adornment + "\n\n" +
+			"``" + path + "`` is an alias of :ref:`mycli echo <mycli-echo>`.\n\n" +
+			"*Auto generated by cobra2snooty*\n"
+		if got := string(b); got != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+		}
+	}
+}
+
+func TestDeprecatedCommands(t *testing.T) {
+	root := &cobra.Command{Use: "mycli"}
+	old := &cobra.Command{Use: "old", Short: "Old command", Deprecated: "use mycli new instead", Run: emptyRun}
+	root.AddCommand(old, &cobra.Command{Use: "new", Short: "New command", Run: emptyRun})
+
+	t.Run("skipped by default", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := GenTreeDocs(root, dir); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "mycli-old.txt")); !os.IsNotExist(err) {
+			t.Fatalf("expected no page for the deprecated command, got: %v", err)
+		}
+		buf := new(bytes.Buffer)
+		if err := GenDocs(root, buf); err != nil {
+			t.Fatal(err)
+		}
+		checkStringOmits(t, buf.String(), "mycli-old")
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := GenTreeDocs(root, dir, WithDeprecatedCommands()); err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(filepath.Join(dir, "mycli-old.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkStringContains(t, string(b), ".. warning::\n\n   This command is deprecated. use mycli new instead\n\nOld command\n")
+
+		b, err = os.ReadFile(filepath.Join(dir, "mycli.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkStringContains(t, string(b), "* :ref:`mycli-old` - Old command\n")
+		checkStringContains(t, string(b), "   old </command/mycli-old>\n")
+
+		b, err = os.ReadFile(filepath.Join(dir, "mycli-new.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkStringOmits(t, string(b), ".. warning::")
+	})
+}
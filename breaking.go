@@ -0,0 +1,92 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import "errors"
+
+var ErrBreakingChange = errors.New("breaking change")
+
+// BreakingChangeError reports a change that can break scripts written for the previous version.
+type BreakingChangeError struct {
+	Change Change
+}
+
+func (e *BreakingChangeError) Error() string {
+	return e.Change.String() + " " + e.Change.BreakingReason()
+}
+
+func (e *BreakingChangeError) Unwrap() error {
+	return ErrBreakingChange
+}
+
+// BreakingReason explains how the change can break scripts written for the previous version,
+// or returns an empty string when the change is additive.
+// Renamed commands are not breaking as the previous name is kept as alias,
+// deprecated commands and flags are not breaking as they still work.
+func (c Change) BreakingReason() string {
+	switch c.Kind {
+	case CommandRemoved:
+		return "Scripts running it fail."
+	case FlagRemoved, FlagRenamed:
+		return "Scripts using the previous option fail."
+	case FlagShorthandChanged:
+		if c.Old != "" {
+			return "Scripts using the previous shorthand fail or set another option."
+		}
+	case FlagTypeChanged:
+		return "Values accepted before may be rejected."
+	case FlagDefaultChanged:
+		return "Scripts not setting the option behave differently."
+	case FlagAdded, ArgAdded:
+		if c.Required {
+			return "Scripts not setting it fail."
+		}
+	case FlagRequiredChanged, ArgRequiredChanged:
+		if c.New == trueValue {
+			return "Scripts not setting it fail."
+		}
+	case ArgRemoved:
+		return "Scripts passing it fail."
+	case CommandAdded, CommandRenamed, CommandDeprecated, FlagDeprecated, ArgRenamed:
+	}
+	return ""
+}
+
+// Breaking reports whether the change can break scripts written for the previous version, see BreakingReason.
+func (c Change) Breaking() bool {
+	return c.BreakingReason() != ""
+}
+
+// BreakingChanges returns the breaking changes among changes.
+func BreakingChanges(changes []Change) []Change {
+	var breaking []Change
+	for _, c := range changes {
+		if c.Breaking() {
+			breaking = append(breaking, c)
+		}
+	}
+	return breaking
+}
+
+// CheckCompatibility compares current to a baseline spec, for example in CI, and reports every
+// breaking change as a *BreakingChangeError. Additive changes are ignored.
+func CheckCompatibility(baseline, current *Spec) error {
+	breaking := BreakingChanges(Diff(baseline, current))
+	errs := make([]error, 0, len(breaking))
+	for _, c := range breaking {
+		errs = append(errs, &BreakingChangeError{Change: c})
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBreaking(t *testing.T) {
+	tests := []struct {
+		change   Change
+		breaking bool
+	}{
+		{Change{Kind: CommandAdded}, false},
+		{Change{Kind: CommandRemoved}, true},
+		{Change{Kind: CommandRenamed}, false},
+		{Change{Kind: CommandDeprecated}, false},
+		{Change{Kind: FlagAdded}, false},
+		{Change{Kind: FlagAdded, Required: true}, true},
+		{Change{Kind: FlagRemoved}, true},
+		{Change{Kind: FlagRenamed}, true},
+		{Change{Kind: FlagDeprecated}, false},
+		{Change{Kind: FlagShorthandChanged, New: "c"}, false},
+		{Change{Kind: FlagShorthandChanged, Old: "c"}, true},
+		{Change{Kind: FlagShorthandChanged, Old: "c", New: "n"}, true},
+		{Change{Kind: FlagTypeChanged, Old: "int", New: "string"}, true},
+		{Change{Kind: FlagDefaultChanged, Old: "1", New: "2"}, true},
+		{Change{Kind: FlagRequiredChanged, Old: "false", New: "true"}, true},
+		{Change{Kind: FlagRequiredChanged, Old: "true", New: "false"}, false},
+		{Change{Kind: ArgAdded}, false},
+		{Change{Kind: ArgAdded, Required: true}, true},
+		{Change{Kind: ArgRemoved}, true},
+		{Change{Kind: ArgRenamed}, false},
+		{Change{Kind: ArgRequiredChanged, Old: "false", New: "true"}, true},
+		{Change{Kind: ArgRequiredChanged, Old: "true", New: "false"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.change.message(func(s string) string { return s }), func(t *testing.T) {
+			if got := tt.change.Breaking(); got != tt.breaking {
+				t.Errorf("expected breaking %v, got %v", tt.breaking, got)
+			}
+		})
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	if err := CheckCompatibility(NewSpec(previousTree()), NewSpec(previousTree())); err != nil {
+		t.Fatalf("expected no breaking change, got: %v", err)
+	}
+
+	err := CheckCompatibility(NewSpec(previousTree()), NewSpec(currentTree()))
+	if !errors.Is(err, ErrBreakingChange) {
+		t.Fatalf("expected breaking changes, got: %v", err)
+	}
+	expected := "mycli old: Removed the mycli old command. Scripts running it fail.\n" +
+		"mycli echo: Changed the default value of the --times option from 1 to 2. Scripts not setting the option behave differently.\n" +
+		"mycli echo: Renamed the --upper option to --uppercase. Scripts using the previous option fail.\n" +
+		"mycli echo: Added the required --color option. Scripts not setting it fail.\n" +
+		"mycli echo: The text argument is now required. Scripts not setting it fail."
+	if err.Error() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, err)
+	}
+	var changeErr *BreakingChangeError
+	if !errors.As(err, &changeErr) || changeErr.Change.Kind != CommandRemoved {
+		t.Errorf("expected a *BreakingChangeError, got: %#v", changeErr)
+	}
+}
+
+func TestCheckCompatibilityDeprecations(t *testing.T) {
+	current := previousTree()
+	say, _, _ := current.Find([]string{"say"})
+	_ = say.Flags().MarkDeprecated("times", "use --count instead")
+	_ = say.Flags().MarkShorthandDeprecated("upper", "use --upper instead")
+	old, _, _ := current.Find([]string{"old"})
+	old.Deprecated = "use mycli say instead"
+
+	if err := CheckCompatibility(NewSpec(previousTree()), NewSpec(current)); err != nil {
+		t.Fatalf("expected no breaking change, got: %v", err)
+	}
+}
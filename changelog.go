@@ -0,0 +1,181 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// String describes the change in plain text.
+func (c Change) String() string {
+	return c.Command + ": " + c.message(func(s string) string { return s })
+}
+
+// message describes the change, formatting names and values with code.
+func (c Change) message(code func(string) string) string {
+	flag := code("--" + c.Name)
+	switch c.Kind {
+	case CommandAdded:
+		return "Added the " + code(c.Command) + " command."
+	case CommandRemoved:
+		return "Removed the " + code(c.Command) + " command."
+	case CommandRenamed:
+		return "Renamed the " + code(c.Old) + " command to " + code(c.New) + "."
+	case CommandDeprecated:
+		return "Deprecated the " + code(c.Command) + " command."
+	case FlagAdded:
+		return "Added the " + requiredPrefix(c.Required) + flag + " option."
+	case FlagRemoved:
+		return "Removed the " + flag + " option."
+	case FlagRenamed:
+		return "Renamed the " + code("--"+c.Old) + " option to " + code("--"+c.New) + "."
+	case FlagDeprecated:
+		return "Deprecated the " + flag + " option."
+	case FlagShorthandChanged:
+		return shorthandMessage(c, flag, code)
+	case FlagTypeChanged:
+		return "Changed the type of the " + flag + " option from " + code(c.Old) + " to " + code(c.New) + "."
+	case FlagDefaultChanged:
+		return "Changed the default value of the " + flag + " option from " + valueOrNone(c.Old, code) + " to " + valueOrNone(c.New, code) + "."
+	case FlagRequiredChanged:
+		return "The " + flag + " option is " + requiredState(c.New, "no longer required") + "."
+	case ArgAdded:
+		return "Added the " + requiredPrefix(c.Required) + code(c.Name) + " argument."
+	case ArgRemoved:
+		return "Removed the " + code(c.Name) + " argument."
+	case ArgRenamed:
+		return "Renamed the " + code(c.Old) + " argument to " + code(c.New) + "."
+	case ArgRequiredChanged:
+		return "The " + code(c.Name) + " argument is " + requiredState(c.New, "now optional") + "."
+	}
+	return string(c.Kind) + "."
+}
+
+func requiredPrefix(required bool) string {
+	if required {
+		return "required "
+	}
+	return ""
+}
+
+func requiredState(required, optional string) string {
+	if required == trueValue {
+		return "now required"
+	}
+	return optional
+}
+
+func shorthandMessage(c Change, flag string, code func(string) string) string {
+	switch {
+	case c.Old == "":
+		return "Added the " + code("-"+c.New) + " shorthand to the " + flag + " option."
+	case c.New == "":
+		return "Removed the " + code("-"+c.Old) + " shorthand of the " + flag + " option."
+	default:
+		return "Changed the shorthand of the " + flag + " option from " + code("-"+c.Old) + " to " + code("-"+c.New) + "."
+	}
+}
+
+func valueOrNone(value string, code func(string) string) string {
+	if value == "" {
+		return "none"
+	}
+	return code(value)
+}
+
+// changelogFormat renders the headings and code of a changelog.
+type changelogFormat struct {
+	section    func(title string) string
+	subsection func(title string) string
+	code       func(text string) string
+}
+
+var (
+	rstChangelog = changelogFormat{
+		section:    func(title string) string { return title + "\n" + strings.Repeat("-", len(title)) + "\n\n" },
+		subsection: func(title string) string { return title + "\n" + strings.Repeat("~", len(title)) + "\n\n" },
+		code:       func(text string) string { return "``" + text + "``" },
+	}
+	markdownChangelog = changelogFormat{
+		section:    func(title string) string { return "## " + title + "\n\n" },
+		subsection: func(title string) string { return "### " + title + "\n\n" },
+		code:       func(text string) string { return "`" + text + "`" },
+	}
+)
+
+// ChangesToRST renders changes as RST sections listing new, removed, renamed and changed commands,
+// suitable for a "What's new" page.
+func ChangesToRST(changes []Change) string {
+	return rstChangelog.render(changes)
+}
+
+// ChangesToMarkdown renders changes like ChangesToRST, as Markdown.
+func ChangesToMarkdown(changes []Change) string {
+	return markdownChangelog.render(changes)
+}
+
+func (f changelogFormat) render(changes []Change) string {
+	buf := new(bytes.Buffer)
+	sections := []struct {
+		title string
+		kind  ChangeKind
+	}{
+		{"New Commands", CommandAdded},
+		{"Removed Commands", CommandRemoved},
+		{"Renamed Commands", CommandRenamed},
+	}
+	for _, section := range sections {
+		var items []string
+		for _, c := range changes {
+			if c.Kind == section.kind {
+				items = append(items, c.message(f.code))
+			}
+		}
+		writeList(buf, f.section(section.title), items)
+	}
+
+	byCommand := map[string][]string{}
+	for _, c := range changes {
+		if c.Kind != CommandAdded && c.Kind != CommandRemoved && c.Kind != CommandRenamed {
+			byCommand[c.Command] = append(byCommand[c.Command], c.message(f.code))
+		}
+	}
+	if len(byCommand) == 0 {
+		return buf.String()
+	}
+	paths := make([]string, 0, len(byCommand))
+	for path := range byCommand {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	buf.WriteString(f.section("Changed Commands"))
+	for _, path := range paths {
+		writeList(buf, f.subsection(f.code(path)), byCommand[path])
+	}
+	return buf.String()
+}
+
+func writeList(buf *bytes.Buffer, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	buf.WriteString(heading)
+	for _, item := range items {
+		buf.WriteString("- " + item + "\n")
+	}
+	buf.WriteString("\n")
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command specdiff compares two JSON specs of a command tree, written with cobra2snooty.WriteSpec,
+// and prints the changes as RST or Markdown.
+// With -check it prints the breaking changes instead and exits with a non-zero status when there are any,
+// to be run in CI against a baseline spec.
+//
+// Usage:
+//
+//	specdiff [-format rst|markdown] [-check] previous.json current.json
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mongodb-labs/cobra2snooty"
+)
+
+var errUsage = errors.New("usage: specdiff [-format rst|markdown] [-check] previous.json current.json")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("specdiff", flag.ContinueOnError)
+	format := flags.String("format", "rst", "output format, rst or markdown")
+	check := flags.Bool("check", false, "fail on breaking changes")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	const specs = 2 // previous and current
+	if flags.NArg() != specs {
+		return errUsage
+	}
+
+	previous, err := readSpec(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	current, err := readSpec(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	if *check {
+		return cobra2snooty.CheckCompatibility(previous, current)
+	}
+
+	changes := cobra2snooty.Diff(previous, current)
+	switch *format {
+	case "rst":
+		_, err = io.WriteString(w, cobra2snooty.ChangesToRST(changes))
+	case "markdown", "md":
+		_, err = io.WriteString(w, cobra2snooty.ChangesToMarkdown(changes))
+	default:
+		err = fmt.Errorf("unknown format %q: %w", *format, errUsage)
+	}
+	return err
+}
+
+func readSpec(filename string) (*cobra2snooty.Spec, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	spec, err := cobra2snooty.ReadSpec(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return spec, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mongodb-labs/cobra2snooty"
+)
+
+func writeSpec(t *testing.T, spec *cobra2snooty.Spec) string {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := cobra2snooty.WriteSpec(buf, spec); err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "spec.json")
+	if err := os.WriteFile(filename, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestRun(t *testing.T) {
+	previous := writeSpec(t, &cobra2snooty.Spec{Commands: []cobra2snooty.CommandSpec{{Path: "mycli"}}})
+	current := writeSpec(t, &cobra2snooty.Spec{Commands: []cobra2snooty.CommandSpec{{Path: "mycli"}, {Path: "mycli new"}}})
+
+	buf := new(bytes.Buffer)
+	if err := run([]string{"-format", "markdown", previous, current}, buf); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "## New Commands\n\n- Added the `mycli new` command.\n\n"; buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := run([]string{"-check", previous, current}, buf); err != nil {
+		t.Errorf("expected no breaking change, got: %v", err)
+	}
+	if err := run([]string{"-check", current, previous}, buf); !errors.Is(err, cobra2snooty.ErrBreakingChange) {
+		t.Errorf("expected breaking change, got: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output with -check, got: %s", buf)
+	}
+
+	if err := run([]string{previous}, buf); !errors.Is(err, errUsage) {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+	if err := run([]string{"-format", "html", previous, current}, buf); !errors.Is(err, errUsage) {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+}
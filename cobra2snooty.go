@@ -22,8 +22,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"sort"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -36,39 +40,53 @@ const (
 
 // GenTreeDocs generates the docs for the full tree of commands.
 func GenTreeDocs(cmd *cobra.Command, dir string, genDocOptions ...GenDocsOption) error {
+	options := newGenDocsOptions(genDocOptions)
+	if options.err != nil {
+		return options.err
+	}
+	if options.autoLinks {
+		// build the linker once for the whole tree rather than once per page
+		genDocOptions = append(slices.Clip(genDocOptions), withAutoLinker(newAutoLinker(cmd.Root(), options)))
+	}
+	if err := genTreeDocs(cmd, dir, options, genDocOptions); err != nil {
+		return err
+	}
+	if options.indexFilename != "" {
+		if err := genFile(options.indexFilename, func(w io.Writer) error {
+			return GenIndexDocs(cmd, w, genDocOptions...)
+		}); err != nil {
+			return err
+		}
+	}
+	if options.redirects != nil {
+		return genRedirectsFile(cmd, options)
+	}
+	return nil
+}
+
+func genTreeDocs(cmd *cobra.Command, dir string, options *GenDocsOptions, genDocOptions []GenDocsOption) error {
 	for _, c := range cmd.Commands() {
-		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+		if !options.isDocumented(c) {
 			continue
 		}
-		if err := GenTreeDocs(c, dir, genDocOptions...); err != nil {
+		if err := genTreeDocs(c, dir, options, genDocOptions); err != nil {
 			return err
 		}
 	}
 
-	basename := strings.ReplaceAll(cmd.CommandPath(), " ", separator) + defaultExtension
-	filename := filepath.Join(dir, basename)
-	f, err := os.Create(filename)
-	if err != nil {
-		return err
+	if options.aliasPages && !options.redirectsAliases() {
+		if err := genAliasFiles(cmd, dir, genDocOptions); err != nil {
+			return err
+		}
 	}
-	defer f.Close()
 
-	return GenDocs(cmd, f, genDocOptions...)
+	basename := strings.ReplaceAll(cmd.CommandPath(), " ", separator) + defaultExtension
+	return genFile(filepath.Join(dir, basename), func(w io.Writer) error {
+		return GenDocs(cmd, w, genDocOptions...)
+	})
 }
 
-const toc = `
-.. default-domain:: mongodb
-
-.. contents:: On this page
-   :local:
-   :backlinks: none
-   :depth: 1
-   :class: singlecol
-`
-
-const syntaxHeader = `Syntax
-------
-
+const syntaxCodeBlock = `
 .. code-block::
    :caption: Command Syntax
 `
@@ -85,81 +103,107 @@ func GenDocs(cmd *cobra.Command, w io.Writer, genDocOptions ...GenDocsOption) er
 	cmd.InitDefaultHelpFlag()
 
 	options := newGenDocsOptions(genDocOptions)
+	if options.err != nil {
+		return options.err
+	}
+	if options.validateExamples {
+		if err := ValidateExamples(cmd); err != nil {
+			return err
+		}
+	}
 
 	buf := new(bytes.Buffer)
 	name := cmd.CommandPath()
 
-	ref := strings.ReplaceAll(name, " ", separator)
+	title := options.sanitizer.escapeLine(name+" command path", name)
+	options.sanitizer.check(name+" short description", cmd.Short, false)
 
-	buf.WriteString(".. _" + ref + ":\n\n")
-	buf.WriteString(strings.Repeat("=", len(name)) + "\n")
-	buf.WriteString(name + "\n")
-	buf.WriteString(strings.Repeat("=", len(name)) + "\n")
-	buf.WriteString(toc)
-	buf.WriteString("\n" + cmd.Short + "\n")
+	buf.WriteString(".. _" + options.label(cmd) + ":\n\n")
+	buf.WriteString(options.pageStyle.title(title))
+	buf.WriteString(options.pageStyle.preamble())
+	if err := printMetadata(buf, cmd, options); err != nil {
+		return err
+	}
+	printBreadcrumbs(buf, cmd, options)
+	printDeprecationWarning(buf, cmd, options)
+	buf.WriteString("\n" + options.formatText(cmd, cmd.Short) + "\n")
 	if long := cmd.Long; long != "" {
 		// remove when https://github.com/spf13/cobra/pull/1495 is released
 		if strings.Contains(name, "completion bash") {
 			long = bashCompletionLong(cmd)
 		}
-		buf.WriteString("\n" + long + "\n")
+		buf.WriteString("\n" + options.formatText(cmd, long) + "\n")
 	}
+	printAliases(buf, cmd)
+	printVersions(buf, cmd, options)
 
 	buf.WriteString("\n")
 
+	if !options.isHelpTopic(cmd) {
+		if err := printCommandSections(buf, cmd, options); err != nil {
+			return err
+		}
+	}
+
+	printRelatedCommands(buf, cmd, options)
+	printSiblingCommands(buf, cmd, options)
+	printAdditionalTopics(buf, cmd, options)
+	printToctree(buf, cmd, options)
+
+	if err := options.sanitizer.err(); err != nil {
+		return err
+	}
+
+	if !cmd.DisableAutoGenTag {
+		buf.WriteString(options.footer() + "\n")
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// printCommandSections prints the sections describing how to run cmd.
+func printCommandSections(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) error {
 	if cmd.Runnable() {
-		buf.WriteString(syntaxHeader)
+		buf.WriteString(options.pageStyle.section("Syntax"))
+		buf.WriteString(syntaxCodeBlock)
 		_, _ = fmt.Fprintf(buf, "\n   %s\n\n", strings.ReplaceAll(cmd.UseLine(), "[flags]", "[options]"))
 		buf.WriteString(".. Code end marker, please don't delete this comment\n\n")
 	}
-	if err := printArgs(buf, cmd); err != nil {
+	if err := printArgs(buf, cmd, options); err != nil {
 		return err
 	}
-	printOptions(buf, cmd)
-
-	printOutputCreate(buf, cmd)
-
-	options.exampleFormatter(buf, cmd)
-
-	if hasRelatedCommands(cmd) {
-		buf.WriteString("Related Commands\n")
-		buf.WriteString("----------------\n\n")
-
-		children := cmd.Commands()
-		sort.Sort(byName(children))
+	options.flagUsages.format = func(text string) string {
+		return options.formatText(cmd, text)
+	}
+	options.flagUsages.sanitizer = options.sanitizer
+	printOptions(buf, cmd, options.flagUsages, options.pageStyle)
 
-		for _, child := range children {
-			if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
-				continue
-			}
-			cname := name + " " + child.Name()
-			ref = strings.ReplaceAll(cname, " ", separator)
-			_, _ = fmt.Fprintf(buf, "* :ref:`%s` - %s\n", ref, child.Short)
-		}
-		buf.WriteString("\n")
-	}
-	if _, ok := cmd.Annotations["toc"]; ok || !cmd.Runnable() {
-		buf.WriteString(tocHeader)
-		buf.WriteString("\n")
-		children := cmd.Commands()
-		sort.Sort(byName(children))
-
-		for _, child := range children {
-			if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
-				continue
-			}
-			cname := name + " " + child.Name()
-			ref = strings.ReplaceAll(cname, " ", separator)
-			_, _ = fmt.Fprintf(buf, "   %s </command/%s>\n", child.Name(), ref)
+	if options.outputFormatter != nil {
+		if err := options.outputFormatter(buf, cmd); err != nil {
+			return err
 		}
-		buf.WriteString("\n")
+	} else if err := printOutputCreate(buf, cmd, options); err != nil {
+		return err
 	}
 
-	if !cmd.DisableAutoGenTag {
-		buf.WriteString("*Auto generated by cobra2snooty on " + options.timeGetter().Format("2-Jan-2006") + "*\n")
+	// the example formatters can't report an invalid annotation
+	if _, err := Examples(cmd); err != nil {
+		return err
 	}
-	_, err := buf.WriteTo(w)
-	return err
+	examples := new(bytes.Buffer)
+	options.exampleFormatter(examples, cmd)
+	// the example formatters don't know the page style
+	if rest, ok := strings.CutPrefix(examples.String(), examplesHeader); ok {
+		buf.WriteString(options.pageStyle.section("Examples") + "\n" + rest)
+	} else {
+		buf.WriteString(examples.String())
+	}
+	return nil
+}
+
+// commandRef returns the name of the page documenting cmd, also its label with the default page style.
+func commandRef(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", separator)
 }
 
 func bashCompletionLong(cmd *cobra.Command) string {
@@ -180,10 +224,10 @@ You will need to start a new shell for this setup to take effect.
 
 // Test to see if we have a reason to print See Also information in docs
 // Basically this is a test for a parent command or a subcommand which is
-// both not deprecated and not the autogenerated help command.
-func hasRelatedCommands(cmd *cobra.Command) bool {
+// both documented and not the autogenerated help command.
+func (o *GenDocsOptions) hasRelatedCommands(cmd *cobra.Command) bool {
 	for _, c := range cmd.Commands() {
-		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+		if !o.isRelatedCommand(c) {
 			continue
 		}
 		return true
@@ -191,6 +235,24 @@ func hasRelatedCommands(cmd *cobra.Command) bool {
 	return false
 }
 
+// isRelatedCommand reports whether cmd is listed in the Related Commands section of its parent.
+func (o *GenDocsOptions) isRelatedCommand(cmd *cobra.Command) bool {
+	return o.isDocumented(cmd) && !cmd.IsAdditionalHelpTopicCommand()
+}
+
+// isDocumented reports whether cmd gets a page, deprecated commands included when enabled.
+func (o *GenDocsOptions) isDocumented(cmd *cobra.Command) bool {
+	if cmd.IsAdditionalHelpTopicCommand() {
+		return o.helpTopics
+	}
+	if cmd.IsAvailableCommand() {
+		return true
+	}
+	// same as IsAvailableCommand, ignoring the deprecation
+	return o.deprecatedCommands && cmd.Deprecated != "" && !cmd.Hidden &&
+		(cmd.Runnable() || cmd.HasAvailableSubCommands())
+}
+
 type byName []*cobra.Command
 
 func (s byName) Len() int           { return len(s) }
@@ -198,14 +260,43 @@ func (s byName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 func (s byName) Less(i, j int) bool { return s[i].Name() < s[j].Name() }
 
 type GenDocsOptions struct {
-	exampleFormatter ExampleFormatter
-	timeGetter       func() time.Time
+	exampleFormatter     ExampleFormatter
+	timeGetter           func() time.Time
+	flagUsages           flagUsagesOptions
+	markdown             bool
+	sanitizer            *sanitizer
+	autoLinks            bool
+	validateExamples     bool
+	outputFuncs          template.FuncMap
+	outputFormatHeadings bool
+	outputStyle          OutputStyle
+	outputFormatter      OutputFormatter
+	linker               *autoLinker
+	dateFormat           string
+	footerText           string
+	omitDate             bool
+	aliasPages           bool
+	deprecatedCommands   bool
+	helpTopics           bool
+	redirects            *redirectsOptions
+	indexFilename        string
+	groupedIndex         bool
+	breadcrumbs          bool
+	siblingLinks         bool
+	metadata             bool
+	keywords             []string
+	facets               []Facet
+	pageStyle            PageStyle
+	// err is set by the options that fail, and returned by the functions generating pages.
+	err error
 }
 
 func newGenDocsOptions(options []GenDocsOption) *GenDocsOptions {
 	o := &GenDocsOptions{
 		exampleFormatter: DefaultExampleFormatter,
+		pageStyle:        MongoDBPageStyle,
 		timeGetter:       DefaultTimeGetter,
+		dateFormat:       defaultDateFormat,
 	}
 
 	for _, option := range options {
@@ -215,6 +306,31 @@ func newGenDocsOptions(options []GenDocsOption) *GenDocsOptions {
 	return o
 }
 
+// label returns the label of the page documenting cmd.
+func (o *GenDocsOptions) label(cmd *cobra.Command) string {
+	return o.pageStyle.label(cmd.CommandPath())
+}
+
+// formatText applies the enabled transformations to the help text of cmd.
+func (o *GenDocsOptions) formatText(cmd *cobra.Command, text string) string {
+	text = o.convertText(cmd.CommandPath()+" help text", text)
+	if o.autoLinks {
+		if o.linker == nil {
+			o.linker = newAutoLinker(cmd.Root(), o)
+		}
+		text = o.linker.link(cmd, text)
+	}
+	return text
+}
+
+// convertText converts text from Markdown when enabled and escapes it.
+func (o *GenDocsOptions) convertText(field, text string) string {
+	if o.markdown {
+		return o.sanitizer.escapeMarkdown(field, text)
+	}
+	return o.sanitizer.escape(field, text)
+}
+
 type GenDocsOption = func(options *GenDocsOptions)
 
 func DefaultTimeGetter() time.Time {
@@ -226,3 +342,81 @@ func WithCustomTimeGetter(customTimeGetter func() time.Time) func(options *GenDo
 		options.timeGetter = customTimeGetter
 	}
 }
+
+// footerDateRegex matches the date of a footer with the word before it, e.g. " on %s".
+var footerDateRegex = regexp.MustCompile(`(?:\s+\w+)?\s+%s|%s\s*`)
+
+const (
+	defaultDateFormat   = "2-Jan-2006"
+	defaultFooter       = "*Auto generated by cobra2snooty on %s*"
+	defaultFooterNoDate = "*Auto generated by cobra2snooty*"
+)
+
+// footer returns the auto generated tag of the page.
+func (o *GenDocsOptions) footer() string {
+	switch {
+	case o.omitDate && o.footerText == "":
+		return defaultFooterNoDate
+	case o.omitDate:
+		return strings.TrimSpace(footerDateRegex.ReplaceAllString(o.footerText, ""))
+	case o.footerText == "":
+		return strings.ReplaceAll(defaultFooter, "%s", o.timeGetter().Format(o.dateFormat))
+	default:
+		return strings.ReplaceAll(o.footerText, "%s", o.timeGetter().Format(o.dateFormat))
+	}
+}
+
+// SourceDateEpochTimeGetter returns the time set in the SOURCE_DATE_EPOCH environment variable,
+// see https://reproducible-builds.org/specs/source-date-epoch/, or the current time when it's not set.
+func SourceDateEpochTimeGetter() time.Time {
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		return time.Unix(epoch, 0).UTC()
+	}
+	return time.Now()
+}
+
+// WithSourceDateEpoch dates the pages with SourceDateEpochTimeGetter.
+func WithSourceDateEpoch() func(options *GenDocsOptions) {
+	return WithCustomTimeGetter(SourceDateEpochTimeGetter)
+}
+
+// WithGitCommitTime dates the pages with the date of the commit checked out in the git repository
+// containing path, read once with GitCommitTime. GenDocs and GenTreeDocs return the error when
+// it can't be read.
+func WithGitCommitTime(path string) func(options *GenDocsOptions) {
+	var once sync.Once
+	var commitTime time.Time
+	var err error
+	return func(options *GenDocsOptions) {
+		once.Do(func() {
+			commitTime, err = GitCommitTime(path)
+		})
+		if err != nil {
+			options.err = fmt.Errorf("reading the git commit time: %w", err)
+			return
+		}
+		options.timeGetter = func() time.Time { return commitTime }
+	}
+}
+
+// WithoutDate omits the generation date from the auto generated tag.
+func WithoutDate() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.omitDate = true
+	}
+}
+
+// WithDateFormat sets the time.Time layout of the generation date, "2-Jan-2006" by default.
+func WithDateFormat(layout string) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.dateFormat = layout
+	}
+}
+
+// WithFooter sets the text of the auto generated tag, where %s is replaced with the generation date.
+// With WithoutDate, %s and the word before it are removed, e.g. "Generated on %s." becomes "Generated.".
+func WithFooter(text string) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.footerText = text
+	}
+}
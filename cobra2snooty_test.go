@@ -187,6 +187,32 @@ func TestGenDocsNoTag(t *testing.T) {
 	checkStringOmits(t, output, unexpected)
 }
 
+func TestGenDocsInvalidExamples(t *testing.T) {
+	c := &cobra.Command{
+		Use:         "mycli",
+		Short:       "Short",
+		Example:     "mycli",
+		Run:         emptyRun,
+		Annotations: map[string]string{ExamplesAnnotation: "{not json"},
+	}
+	for name, formatter := range map[string]ExampleFormatter{
+		"default":    DefaultExampleFormatter,
+		"shell tabs": ShellTabsExampleFormatter(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := GenDocs(c, new(bytes.Buffer), WithCustomExampleFormatter(formatter))
+			if err == nil || !strings.Contains(err.Error(), "invalid examples annotation") {
+				t.Fatalf("expected the invalid annotation error, got %v", err)
+			}
+			buf := new(bytes.Buffer)
+			formatter(buf, c)
+			if buf.Len() != 0 {
+				t.Errorf("expected no examples, got:\n%s", buf.String())
+			}
+		})
+	}
+}
+
 func TestGenTreeDocs(t *testing.T) {
 	c := &cobra.Command{
 		Use: "do <arg1> [arg2]",
@@ -343,6 +369,90 @@ func TestGenDocsSnapshots(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name: "option_directives",
+			cmd: func() *cobra.Command {
+				c := &cobra.Command{
+					Use:  "option_directives",
+					Long: "Testing options rendered as option directives",
+					Run:  emptyRun,
+				}
+				c.Flags().StringP("name", "n", "default", "name to use")
+				c.Flags().Bool("force", false, "skip confirmation")
+				return c
+			}(),
+			options: []GenDocsOption{
+				WithCustomTimeGetter(func() time.Time {
+					return time.Date(2025, 3, 5, 17, 0, 0, 0, time.UTC)
+				}),
+				WithOptionDirectives(),
+			},
+		},
+		{
+			name: "structured_example",
+			cmd: func() *cobra.Command {
+				c := &cobra.Command{
+					Use:     "structured_example",
+					Long:    "Testing example output using structured examples",
+					Example: "legacy example ignored",
+				}
+				if err := SetExamples(c,
+					Example{
+						Title:       "Open the docs",
+						Description: "Open the docs on the # section.",
+						Command:     "structured_example --url https://example.com/#section",
+						Output:      "opened",
+						Language:    "sh",
+					},
+					Example{Command: "structured_example"},
+				); err != nil {
+					panic(err)
+				}
+				return c
+			}(),
+			options: []GenDocsOption{
+				WithCustomTimeGetter(func() time.Time {
+					return time.Date(2025, 3, 5, 17, 0, 0, 0, time.UTC)
+				}),
+			},
+		},
+		{
+			name: "shell_tabs_example",
+			cmd: &cobra.Command{
+				Use:     "shell_tabs_example",
+				Long:    "Testing example output using shell tabs",
+				Example: "# Print a quoted value\nshell_tabs_example --value 'a b'",
+			},
+			options: []GenDocsOption{
+				WithCustomTimeGetter(func() time.Time {
+					return time.Date(2025, 3, 5, 17, 0, 0, 0, time.UTC)
+				}),
+				WithShellTabs(),
+			},
+		},
+		{
+			name: "output_formats",
+			cmd: func() *cobra.Command {
+				c := &cobra.Command{
+					Use:  "output_formats",
+					Long: "Testing output section with multiple formats",
+					Annotations: map[string]string{
+						"output": "ID\tNAME\n{{range .}}{{.ID}}\t{{.Name}}\n{{end}}",
+					},
+				}
+				if err := SetOutputFormats(c,
+					OutputFormat{Name: "JSON", Language: "json", Content: `[{"id":"1","name":"orders"}]`},
+				); err != nil {
+					panic(err)
+				}
+				return c
+			}(),
+			options: []GenDocsOption{
+				WithCustomTimeGetter(func() time.Time {
+					return time.Date(2025, 3, 5, 17, 0, 0, 0, time.UTC)
+				}),
+			},
+		},
 	}
 
 	// Run tests
@@ -367,3 +477,102 @@ func TestGenDocsSnapshots(t *testing.T) {
 		})
 	}
 }
+
+func TestGenDocsFlagPolicy(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		c := &cobra.Command{Use: "policy", Run: emptyRun}
+		c.Flags().String("internal", "", "internal only flag")
+		_ = c.Flags().MarkHidden("internal")
+		c.Flags().String("old", "", "old flag")
+		_ = c.Flags().MarkDeprecated("old", "use --new instead")
+		c.Flags().StringP("new", "n", "", "new flag")
+		_ = c.Flags().MarkShorthandDeprecated("new", "use --new instead")
+		return c
+	}
+
+	t.Run("default", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := GenDocs(newCmd(), buf); err != nil {
+			t.Fatal(err)
+		}
+		output := buf.String()
+		checkStringOmits(t, output, "--internal")
+		checkStringOmits(t, output, "--old")
+		checkStringOmits(t, output, "Deprecated Options")
+		checkStringOmits(t, output, "-n,")
+	})
+
+	t.Run("hidden", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := GenDocs(newCmd(), buf, WithHiddenFlags()); err != nil {
+			t.Fatal(err)
+		}
+		output := buf.String()
+		checkStringContains(t, output, "--internal")
+		checkStringContains(t, output, "old flag (DEPRECATED: use --new instead)")
+	})
+
+	t.Run("deprecated section", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := GenDocs(newCmd(), buf, WithHiddenFlags(), WithDeprecatedOptionsSection(), WithDeprecatedShorthands()); err != nil {
+			t.Fatal(err)
+		}
+		output := buf.String()
+		_, deprecated, found := strings.Cut(output, "Deprecated Options")
+		if !found {
+			t.Fatalf("expected a Deprecated Options section, got:\n%s", output)
+		}
+		checkStringContains(t, deprecated, "   * - --old\n     - string\n     - false\n     - old flag\n\n       use --new instead\n")
+		checkStringOmits(t, output, "(DEPRECATED: use --new instead)")
+		checkStringContains(t, output, "new flag (DEPRECATED SHORTHAND -n: use --new instead)")
+	})
+}
+
+func TestFlagReferences(t *testing.T) {
+	Root()
+	if got, want := FlagLabel(Echo(), "strone"), "root-echo-option-strone"; got != want {
+		t.Errorf("FlagLabel() = %s, want %s", got, want)
+	}
+	if got, want := FlagRef(Echo(), "strone"), ":option:`root echo --strone`"; got != want {
+		t.Errorf("FlagRef() = %s, want %s", got, want)
+	}
+}
+
+func TestGenDocsFooter(t *testing.T) {
+	cmd := &cobra.Command{Use: "footer", Run: emptyRun}
+
+	t.Run("source date epoch", func(t *testing.T) {
+		t.Setenv("SOURCE_DATE_EPOCH", "1741194000")
+		buf := new(bytes.Buffer)
+		if err := GenDocs(cmd, buf, WithSourceDateEpoch(), WithDateFormat("2006-01-02")); err != nil {
+			t.Fatal(err)
+		}
+		checkStringContains(t, buf.String(), "*Auto generated by cobra2snooty on 2025-03-05*\n")
+	})
+
+	t.Run("custom footer", func(t *testing.T) {
+		t.Setenv("SOURCE_DATE_EPOCH", "1741194000")
+		buf := new(bytes.Buffer)
+		if err := GenDocs(cmd, buf, WithSourceDateEpoch(), WithFooter("Generated on %s.")); err != nil {
+			t.Fatal(err)
+		}
+		checkStringContains(t, buf.String(), "Generated on 5-Mar-2025.\n")
+	})
+
+	t.Run("without date", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := GenDocs(cmd, buf, WithoutDate()); err != nil {
+			t.Fatal(err)
+		}
+		checkStringContains(t, buf.String(), "*Auto generated by cobra2snooty*\n")
+	})
+
+	t.Run("custom footer without date", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := GenDocs(cmd, buf, WithoutDate(), WithFooter("Generated on %s.")); err != nil {
+			t.Fatal(err)
+		}
+		checkStringContains(t, buf.String(), "Generated.\n")
+		checkStringOmits(t, buf.String(), "%s")
+	})
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ChangeKind is the kind of a Change between two specs.
+type ChangeKind string
+
+const (
+	CommandAdded         ChangeKind = "command_added"
+	CommandRemoved       ChangeKind = "command_removed"
+	CommandRenamed       ChangeKind = "command_renamed"
+	CommandDeprecated    ChangeKind = "command_deprecated"
+	FlagAdded            ChangeKind = "flag_added"
+	FlagRemoved          ChangeKind = "flag_removed"
+	FlagRenamed          ChangeKind = "flag_renamed"
+	FlagDeprecated       ChangeKind = "flag_deprecated"
+	FlagShorthandChanged ChangeKind = "flag_shorthand_changed"
+	FlagTypeChanged      ChangeKind = "flag_type_changed"
+	FlagDefaultChanged   ChangeKind = "flag_default_changed"
+	FlagRequiredChanged  ChangeKind = "flag_required_changed"
+	ArgAdded             ChangeKind = "arg_added"
+	ArgRemoved           ChangeKind = "arg_removed"
+	ArgRenamed           ChangeKind = "arg_renamed"
+	ArgRequiredChanged   ChangeKind = "arg_required_changed"
+)
+
+// Change is a single difference between two specs.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// Command is the path of the command in the new spec, or in the old one when it was removed.
+	Command string `json:"command"`
+	// Name is the name of the flag or argument in the new spec, or in the old one when it was removed.
+	Name string `json:"name,omitempty"`
+	// Old and New are the previous and current values of what changed:
+	// a command path, a flag or argument name, a shorthand, a type, a default, a required-ness
+	// or, for deprecations, the deprecation message.
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+	// Required is true for added flags and arguments that are required.
+	Required bool `json:"required,omitempty"`
+}
+
+// DiffCommands compares the trees of two versions of a root command, see Diff.
+func DiffCommands(previous, current *cobra.Command) []Change {
+	return Diff(NewSpec(previous), NewSpec(current))
+}
+
+// Diff reports the commands, flags and arguments added, removed, renamed or changed from previous to current.
+// A removed command is considered renamed when a command of the same parent has its name as alias,
+// a removed flag is considered renamed when a flag of the same command and type has the same
+// shorthand or usage.
+func Diff(previous, current *Spec) []Change {
+	renames := commandRenames(previous, current, nil)
+	var changes []Change
+	matched := map[string]bool{}
+	for i := range previous.Commands {
+		prev := &previous.Commands[i]
+		path := renamedPath(prev.Path, renames)
+		cur := current.Command(path)
+		if cur == nil {
+			changes = append(changes, Change{Kind: CommandRemoved, Command: prev.Path})
+			continue
+		}
+		matched[path] = true
+		if renamed, ok := renames[prev.Path]; ok {
+			changes = append(changes, Change{Kind: CommandRenamed, Command: renamed, Old: prev.Path, New: renamed})
+		}
+		if prev.Deprecated == "" && cur.Deprecated != "" {
+			changes = append(changes, Change{Kind: CommandDeprecated, Command: cur.Path, New: cur.Deprecated})
+		}
+		changes = append(changes, diffFlags(previous, current, prev, cur)...)
+		changes = append(changes, diffArgs(prev, cur)...)
+	}
+	for _, cur := range current.Commands {
+		if !matched[cur.Path] {
+			changes = append(changes, Change{Kind: CommandAdded, Command: cur.Path})
+		}
+	}
+	return changes
+}
+
+// commandRenames maps the paths of the commands renamed from previous to current to their new path,
+// completing the given renames.
+func commandRenames(previous, current *Spec, known map[string]string) map[string]string {
+	renames := maps.Clone(known)
+	if renames == nil {
+		renames = map[string]string{}
+	}
+	// sorted by path, parents are renamed before their children, whatever the order of the spec
+	commands := slices.Clone(previous.Commands)
+	slices.SortFunc(commands, func(a, b CommandSpec) int { return strings.Compare(a.Path, b.Path) })
+	for _, prev := range commands {
+		if current.Command(renamedPath(prev.Path, renames)) != nil {
+			continue
+		}
+		parent, name := splitCommandPath(prev.Path)
+		parent = renamedPath(parent, renames)
+		for _, cur := range current.Commands {
+			curParent, _ := splitCommandPath(cur.Path)
+			if curParent == parent && previous.Command(cur.Path) == nil && slices.Contains(cur.Aliases, name) {
+				renames[prev.Path] = cur.Path
+				break
+			}
+		}
+	}
+	return renames
+}
+
+// renamedPath returns the path of a command in the new spec, applying the rename of its closest renamed ancestor.
+func renamedPath(path string, renames map[string]string) string {
+	for parent := path; parent != ""; parent, _ = splitCommandPath(parent) {
+		if renamed, ok := renames[parent]; ok {
+			return renamed + path[len(parent):]
+		}
+	}
+	return path
+}
+
+func splitCommandPath(path string) (parent, name string) {
+	i := strings.LastIndex(path, " ")
+	if i < 0 {
+		return "", path
+	}
+	return path[:i], path[i+1:]
+}
+
+// inheritedFlag returns the persistent flag called name defined by an ancestor of the command at path.
+func inheritedFlag(spec *Spec, path, name string) *FlagSpec {
+	for parent, _ := splitCommandPath(path); parent != ""; parent, _ = splitCommandPath(parent) {
+		if cmd := spec.Command(parent); cmd != nil {
+			if flag := cmd.Flag(name); flag != nil && flag.Persistent {
+				return flag
+			}
+		}
+	}
+	return nil
+}
+
+func diffFlags(previous, current *Spec, prev, cur *CommandSpec) []Change {
+	var changes []Change
+	var removed, added []FlagSpec
+	for _, flag := range prev.Flags {
+		if newFlag := cur.Flag(flag.Name); newFlag != nil {
+			changes = append(changes, diffFlag(cur.Path, flag, *newFlag)...)
+		} else if inheritedFlag(current, cur.Path, flag.Name) == nil {
+			removed = append(removed, flag)
+		}
+	}
+	for _, flag := range cur.Flags {
+		if prev.Flag(flag.Name) == nil && inheritedFlag(previous, prev.Path, flag.Name) == nil {
+			added = append(added, flag)
+		}
+	}
+
+	for _, flag := range removed {
+		i := slices.IndexFunc(added, func(candidate FlagSpec) bool { return isFlagRename(flag, candidate) })
+		if i < 0 {
+			changes = append(changes, Change{Kind: FlagRemoved, Command: cur.Path, Name: flag.Name})
+			continue
+		}
+		renamed := added[i]
+		added = slices.Delete(added, i, i+1)
+		changes = append(changes, Change{Kind: FlagRenamed, Command: cur.Path, Name: renamed.Name, Old: flag.Name, New: renamed.Name})
+		changes = append(changes, diffFlag(cur.Path, flag, renamed)...)
+	}
+	for _, flag := range added {
+		changes = append(changes, Change{Kind: FlagAdded, Command: cur.Path, Name: flag.Name, Required: flag.Required})
+	}
+	return changes
+}
+
+func isFlagRename(removed, added FlagSpec) bool {
+	if removed.Type != added.Type {
+		return false
+	}
+	return removed.Shorthand != "" && removed.Shorthand == added.Shorthand ||
+		removed.Usage != "" && removed.Usage == added.Usage
+}
+
+func diffFlag(path string, prev, cur FlagSpec) []Change {
+	var changes []Change
+	change := func(kind ChangeKind, previous, current string) {
+		if previous != current {
+			changes = append(changes, Change{Kind: kind, Command: path, Name: cur.Name, Old: previous, New: current})
+		}
+	}
+	change(FlagShorthandChanged, prev.Shorthand, cur.Shorthand)
+	change(FlagTypeChanged, prev.Type, cur.Type)
+	change(FlagDefaultChanged, prev.Default, cur.Default)
+	change(FlagRequiredChanged, strconv.FormatBool(prev.Required), strconv.FormatBool(cur.Required))
+	if prev.Deprecated == "" && cur.Deprecated != "" {
+		changes = append(changes, Change{Kind: FlagDeprecated, Command: path, Name: cur.Name, New: cur.Deprecated})
+	}
+	return changes
+}
+
+// diffArgs compares the positional arguments by position.
+func diffArgs(prev, cur *CommandSpec) []Change {
+	var changes []Change
+	for i := 0; i < len(prev.Args) || i < len(cur.Args); i++ {
+		switch {
+		case i >= len(cur.Args):
+			changes = append(changes, Change{Kind: ArgRemoved, Command: cur.Path, Name: prev.Args[i].Name})
+		case i >= len(prev.Args):
+			changes = append(changes, Change{Kind: ArgAdded, Command: cur.Path, Name: cur.Args[i].Name, Required: cur.Args[i].Required})
+		default:
+			old, arg := prev.Args[i], cur.Args[i]
+			if old.Name != arg.Name {
+				changes = append(changes, Change{Kind: ArgRenamed, Command: cur.Path, Name: arg.Name, Old: old.Name, New: arg.Name})
+			}
+			if old.Required != arg.Required {
+				changes = append(changes, Change{
+					Kind:    ArgRequiredChanged,
+					Command: cur.Path,
+					Name:    arg.Name,
+					Old:     strconv.FormatBool(old.Required),
+					New:     strconv.FormatBool(arg.Required),
+				})
+			}
+		}
+	}
+	return changes
+}
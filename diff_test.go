@@ -0,0 +1,171 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func previousTree() *cobra.Command {
+	root := &cobra.Command{Use: "mycli"}
+	root.PersistentFlags().StringP("profile", "P", "default", "profile to use")
+	say := &cobra.Command{Use: "say [text]", Short: "Say text", Run: emptyRun}
+	say.Flags().BoolP("upper", "u", false, "upper case")
+	say.Flags().Int("times", 1, "repetitions")
+	config := &cobra.Command{Use: "config"}
+	set := &cobra.Command{Use: "set <key> <value>", Run: emptyRun}
+	set.Flags().Bool("force", false, "overwrite")
+	config.AddCommand(set)
+	root.AddCommand(say, &cobra.Command{Use: "old", Run: emptyRun}, config)
+	return root
+}
+
+func currentTree() *cobra.Command {
+	root := &cobra.Command{Use: "mycli"}
+	root.PersistentFlags().StringP("profile", "P", "default", "profile to use")
+	echo := &cobra.Command{Use: "echo <text>", Aliases: []string{"say"}, Short: "Echo text", Run: emptyRun}
+	echo.Flags().BoolP("uppercase", "u", false, "upper case output")
+	echo.Flags().Int("times", 2, "repetitions")
+	echo.Flags().String("color", "", "output color")
+	_ = echo.MarkFlagRequired("color")
+	config := &cobra.Command{Use: "config"}
+	config.PersistentFlags().Bool("force", false, "overwrite")
+	config.AddCommand(&cobra.Command{Use: "set <key> [value]", Run: emptyRun})
+	root.AddCommand(echo, &cobra.Command{Use: "new", Run: emptyRun}, config)
+	return root
+}
+
+func TestDiffCommands(t *testing.T) {
+	expected := []Change{
+		{Kind: FlagAdded, Command: "mycli config", Name: "force"},
+		{Kind: ArgRequiredChanged, Command: "mycli config set", Name: "value", Old: "true", New: "false"},
+		{Kind: CommandRemoved, Command: "mycli old"},
+		{Kind: CommandRenamed, Command: "mycli echo", Old: "mycli say", New: "mycli echo"},
+		{Kind: FlagDefaultChanged, Command: "mycli echo", Name: "times", Old: "1", New: "2"},
+		{Kind: FlagRenamed, Command: "mycli echo", Name: "uppercase", Old: "upper", New: "uppercase"},
+		{Kind: FlagAdded, Command: "mycli echo", Name: "color", Required: true},
+		{Kind: ArgRequiredChanged, Command: "mycli echo", Name: "text", Old: "false", New: "true"},
+		{Kind: CommandAdded, Command: "mycli new"},
+	}
+	if got := DiffCommands(previousTree(), currentTree()); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected:\n%v\ngot:\n%v", expected, got)
+	}
+	if got := DiffCommands(currentTree(), currentTree()); len(got) != 0 {
+		t.Errorf("expected no changes, got: %v", got)
+	}
+}
+
+func TestDiffDeprecations(t *testing.T) {
+	current := currentTree()
+	echo, _, _ := current.Find([]string{"echo"})
+	_ = echo.Flags().MarkDeprecated("times", "use --count instead")
+	newCmd, _, _ := current.Find([]string{"new"})
+	newCmd.Deprecated = "use mycli echo instead"
+
+	spec := NewSpec(current)
+	if flag := spec.Command("mycli echo").Flag("times"); flag == nil || flag.Deprecated != "use --count instead" {
+		t.Fatalf("expected the deprecated flag in the spec, got: %+v", flag)
+	}
+	expected := []Change{
+		{Kind: FlagDeprecated, Command: "mycli echo", Name: "times", New: "use --count instead"},
+		{Kind: CommandDeprecated, Command: "mycli new", New: "use mycli echo instead"},
+	}
+	if got := Diff(NewSpec(currentTree()), spec); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected:\n%v\ngot:\n%v", expected, got)
+	}
+}
+
+func TestDiffFlagChanges(t *testing.T) {
+	previous := &Spec{Commands: []CommandSpec{{
+		Path: "mycli",
+		Args: []ArgSpec{{Name: "a", Required: true}, {Name: "b"}},
+		Flags: []FlagSpec{
+			{Name: "count", Shorthand: "c", Type: "int", Default: "0"},
+			{Name: "name", Type: "string", Required: true},
+		},
+	}}}
+	current := &Spec{Commands: []CommandSpec{{
+		Path: "mycli",
+		Args: []ArgSpec{{Name: "first", Required: true}},
+		Flags: []FlagSpec{
+			{Name: "count", Shorthand: "n", Type: "string", Default: ""},
+			{Name: "name", Shorthand: "N", Type: "string"},
+		},
+	}}}
+	expected := []Change{
+		{Kind: FlagShorthandChanged, Command: "mycli", Name: "count", Old: "c", New: "n"},
+		{Kind: FlagTypeChanged, Command: "mycli", Name: "count", Old: "int", New: "string"},
+		{Kind: FlagDefaultChanged, Command: "mycli", Name: "count", Old: "0", New: ""},
+		{Kind: FlagShorthandChanged, Command: "mycli", Name: "name", Old: "", New: "N"},
+		{Kind: FlagRequiredChanged, Command: "mycli", Name: "name", Old: "true", New: "false"},
+		{Kind: ArgRenamed, Command: "mycli", Name: "first", Old: "a", New: "first"},
+		{Kind: ArgRemoved, Command: "mycli", Name: "b"},
+	}
+	if got := Diff(previous, current); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected:\n%v\ngot:\n%v", expected, got)
+	}
+}
+
+func TestChangesToRST(t *testing.T) {
+	expected := "New Commands\n" +
+		"------------\n\n" +
+		"- Added the ``mycli new`` command.\n\n" +
+		"Removed Commands\n" +
+		"----------------\n\n" +
+		"- Removed the ``mycli old`` command.\n\n" +
+		"Renamed Commands\n" +
+		"----------------\n\n" +
+		"- Renamed the ``mycli say`` command to ``mycli echo``.\n\n" +
+		"Changed Commands\n" +
+		"----------------\n\n" +
+		"``mycli config``\n" +
+		"~~~~~~~~~~~~~~~~\n\n" +
+		"- Added the ``--force`` option.\n\n" +
+		"``mycli config set``\n" +
+		"~~~~~~~~~~~~~~~~~~~~\n\n" +
+		"- The ``value`` argument is now optional.\n\n" +
+		"``mycli echo``\n" +
+		"~~~~~~~~~~~~~~\n\n" +
+		"- Changed the default value of the ``--times`` option from ``1`` to ``2``.\n" +
+		"- Renamed the ``--upper`` option to ``--uppercase``.\n" +
+		"- Added the required ``--color`` option.\n" +
+		"- The ``text`` argument is now required.\n\n"
+	if got := ChangesToRST(DiffCommands(previousTree(), currentTree())); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestChangesToMarkdown(t *testing.T) {
+	changes := []Change{
+		{Kind: CommandAdded, Command: "mycli new"},
+		{Kind: FlagShorthandChanged, Command: "mycli echo", Name: "times", Old: "t"},
+		{Kind: FlagDefaultChanged, Command: "mycli echo", Name: "color", New: "red"},
+	}
+	expected := "## New Commands\n\n" +
+		"- Added the `mycli new` command.\n\n" +
+		"## Changed Commands\n\n" +
+		"### `mycli echo`\n\n" +
+		"- Removed the `-t` shorthand of the `--times` option.\n" +
+		"- Changed the default value of the `--color` option from none to `red`.\n\n"
+	if got := ChangesToMarkdown(changes); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+	if got := changes[1].String(); got != "mycli echo: Removed the -t shorthand of the --times option." {
+		t.Errorf("unexpected String(): %s", got)
+	}
+}
@@ -15,8 +15,11 @@
 package cobra2snooty
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -28,11 +31,67 @@ const (
 
 `
 	identChar = " "
+	// ExamplesAnnotation is the command annotation holding the JSON encoded structured examples.
+	ExamplesAnnotation = "examples"
 )
 
+// Example is a structured usage example of a command.
+type Example struct {
+	// Title is used as the caption of the command code-block.
+	Title string `json:"title,omitempty"`
+	// Description is a paragraph printed before the command.
+	Description string `json:"description,omitempty"`
+	// Command is the command line to run.
+	Command string `json:"command"`
+	// Output is the optional output expected from the command.
+	Output string `json:"output,omitempty"`
+	// Language is the language of the command code-block, for example "sh".
+	Language string `json:"language,omitempty"`
+	// Shells holds variants of Command for other shells, keyed by Shell.ID.
+	// When present the example is rendered as a tabs block.
+	Shells map[string]string `json:"shells,omitempty"`
+}
+
+// SetExamples stores structured examples in the ExamplesAnnotation of cmd.
+func SetExamples(cmd *cobra.Command, examples ...Example) error {
+	b, err := json.Marshal(examples)
+	if err != nil {
+		return err
+	}
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[ExamplesAnnotation] = string(b)
+	return nil
+}
+
+// Examples returns the structured examples of cmd, if any.
+func Examples(cmd *cobra.Command) ([]Example, error) {
+	annotation, ok := cmd.Annotations[ExamplesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var examples []Example
+	if err := json.Unmarshal([]byte(annotation), &examples); err != nil {
+		return nil, fmt.Errorf("%s: invalid %s annotation: %w", cmd.CommandPath(), ExamplesAnnotation, err)
+	}
+	return examples, nil
+}
+
 type ExampleFormatter func(w io.Writer, cmd *cobra.Command)
 
+// DefaultExampleFormatter prints the structured examples of cmd and falls back
+// to parsing cmd.Example when there are none.
+// An invalid ExamplesAnnotation prints nothing, GenDocs returns its error.
 func DefaultExampleFormatter(w io.Writer, cmd *cobra.Command) {
+	examples, err := Examples(cmd)
+	if err != nil {
+		return
+	}
+	if len(examples) > 0 {
+		printStructuredExamples(w, examples, nil)
+		return
+	}
 	if cmd.Example != "" {
 		printExamples(w, cmd)
 	}
@@ -61,3 +120,121 @@ func printExamples(w io.Writer, cmd *cobra.Command) {
 		_, _ = fmt.Fprintf(w, "\n  %s%s\n", comment, indentString(example, identChar))
 	}
 }
+
+// ShellTabsExampleFormatter returns an ExampleFormatter rendering every example as a tabs block
+// with one tab per shell, DefaultShells when none is given.
+// An invalid ExamplesAnnotation prints nothing, GenDocs returns its error.
+func ShellTabsExampleFormatter(shells ...Shell) ExampleFormatter {
+	if len(shells) == 0 {
+		shells = DefaultShells
+	}
+	return func(w io.Writer, cmd *cobra.Command) {
+		examples, err := Examples(cmd)
+		if err != nil {
+			return
+		}
+		if len(examples) == 0 {
+			examples = legacyExamples(cmd)
+		}
+		if len(examples) > 0 {
+			printStructuredExamples(w, examples, shells)
+		}
+	}
+}
+
+// legacyExamples splits cmd.Example like printExamples does.
+func legacyExamples(cmd *cobra.Command) []Example {
+	if cmd.Example == "" {
+		return nil
+	}
+	chunks := strings.Split(strings.TrimLeft(cmd.Example, " #"), "# ")
+	examples := make([]Example, 0, len(chunks))
+	for _, chunk := range chunks {
+		lines := strings.Split(strings.TrimSpace(chunk), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSpace(line)
+		}
+		code := strings.Join(lines, "\n")
+		if strings.Contains(cmd.Example, "#") {
+			code = "# " + code
+		}
+		examples = append(examples, Example{Command: code})
+	}
+	return examples
+}
+
+// shellsFor returns the shells to render example for: the given shells or,
+// when there are none, the shells of its explicit variants.
+func shellsFor(example Example, shells []Shell) []Shell {
+	if len(shells) > 0 || len(example.Shells) == 0 {
+		return shells
+	}
+	result := []Shell{Bash}
+	known := map[string]bool{Bash.ID: true}
+	for _, shell := range DefaultShells {
+		if _, ok := example.Shells[shell.ID]; ok && !known[shell.ID] {
+			result = append(result, shell)
+			known[shell.ID] = true
+		}
+	}
+	var unknown []string
+	for id := range example.Shells {
+		if !known[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+	for _, id := range unknown {
+		result = append(result, Shell{ID: id, Name: id})
+	}
+	return result
+}
+
+func printStructuredExamples(w io.Writer, examples []Example, shells []Shell) {
+	_, _ = w.Write([]byte(examplesHeader))
+	for _, example := range examples {
+		if example.Description != "" {
+			_, _ = fmt.Fprintf(w, "%s\n\n", strings.TrimSpace(example.Description))
+		}
+		if exampleShells := shellsFor(example, shells); len(exampleShells) > 0 {
+			printShellTabs(w, example, exampleShells)
+		} else {
+			printCodeBlock(w, example.Language, example.Title, example.Command)
+		}
+		if example.Output != "" {
+			caption := "Output"
+			if example.Title != "" {
+				caption = example.Title + " output"
+			}
+			printCodeBlock(w, "", caption, example.Output)
+		}
+	}
+}
+
+func printCodeBlock(w io.Writer, language, caption, code string) {
+	_, _ = w.Write([]byte(strings.TrimSpace(".. code-block:: " + language)))
+	_, _ = w.Write([]byte("\n"))
+	if caption != "" {
+		_, _ = fmt.Fprintf(w, "   :caption: %s\n", caption)
+	}
+	_, _ = w.Write([]byte("   :copyable: false\n\n"))
+	_, _ = fmt.Fprintf(w, "%s\n\n", indentString(strings.TrimRight(code, "\n"), "   "))
+}
+
+func printShellTabs(w io.Writer, example Example, shells []Shell) {
+	_, _ = w.Write([]byte(".. tabs::\n\n"))
+	for _, shell := range shells {
+		code, ok := example.Shells[shell.ID]
+		if !ok && shell.Convert != nil {
+			code = shell.Convert(example.Command)
+		}
+		language := shell.Language
+		if language == "" || shell.ID == Bash.ID && example.Language != "" {
+			language = example.Language
+		}
+		_, _ = fmt.Fprintf(w, "   .. tab:: %s\n      :tabid: %s\n\n", shell.Name, shell.ID)
+		block := new(bytes.Buffer)
+		printCodeBlock(block, language, example.Title, code)
+		_, _ = w.Write([]byte(indentString(block.String(), "      ")))
+	}
+}
@@ -28,6 +28,7 @@ const (
 	boolType   = "bool"
 	countType  = "count"
 	falseValue = "false"
+	trueValue  = "true"
 	nilValue   = "<nil>"
 )
 
@@ -37,109 +38,228 @@ var (
 	}
 )
 
-func FlagUsages(f *pflag.FlagSet) string {
-	buf := new(bytes.Buffer)
+// FlagUsages returns the list-table rows documenting the visible flags in f.
+// The flag options, WithMarkdown and WithEscaping apply to the rows. Text WithStrictEscaping
+// rejects is escaped, but ErrUnsafeText is only returned by GenDocs.
+func FlagUsages(f *pflag.FlagSet, genDocOptions ...GenDocsOption) string {
+	options := newGenDocsOptions(genDocOptions)
+	o := options.flagUsages
+	o.sanitizer = options.sanitizer
+	o.format = func(text string) string {
+		return options.convertText("flag usage", text)
+	}
+	return flagRows(documentedFlags(f, o, false))
+}
+
+// flagUsagesOptions controls which flags are documented and how deprecations are shown.
+type flagUsagesOptions struct {
+	includeHidden        bool
+	deprecatedSection    bool
+	deprecatedShorthands bool
+	directives           bool
+	format               func(text string) string
+	sanitizer            *sanitizer
+}
+
+// isDocumented returns true if the flag belongs in the main options tables.
+func (o flagUsagesOptions) isDocumented(flag *pflag.Flag) bool {
+	if flag.Deprecated != "" && o.deprecatedSection {
+		return false
+	}
+	return !flag.Hidden || o.includeHidden
+}
 
+// hasDocumentedFlags returns true if any flag in f belongs in the main options tables.
+func (o flagUsagesOptions) hasDocumentedFlags(f *pflag.FlagSet) bool {
+	found := false
 	f.VisitAll(func(flag *pflag.Flag) {
-		if flag.Hidden {
+		found = found || o.isDocumented(flag)
+	})
+	return found
+}
+
+// documentedFlags returns the docs for the flags of f that belong in the main options tables,
+// or only the deprecated ones when deprecated is true.
+func documentedFlags(f *pflag.FlagSet, o flagUsagesOptions, deprecated bool) []flagDoc {
+	var docs []flagDoc
+	f.VisitAll(func(flag *pflag.Flag) {
+		if deprecated && flag.Deprecated == "" {
+			return
+		}
+		if !deprecated && !o.isDocumented(flag) {
 			return
 		}
+		docs = append(docs, newFlagDoc(flag, o, deprecated))
+	})
+	return docs
+}
 
-		var line string
-		varname, usage := pflag.UnquoteUsage(flag)
-		varname = mapVarname(varname)
-		const defaultIndentation = 6
-		usage = strings.ReplaceAll(usage, "\n", "\n"+strings.Repeat(" ", defaultIndentation))
+// flagDoc holds the documented parts of a single flag.
+type flagDoc struct {
+	flag        *pflag.Flag
+	names       string
+	varname     string
+	required    bool
+	noOptDefVal string
+	description string
+}
 
-		if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
-			line = fmt.Sprintf("  * - -%s, --%s\n    - %s", flag.Shorthand, flag.Name, varname)
-		} else {
-			line = fmt.Sprintf("  * - --%s\n    - %s", flag.Name, varname)
-		}
+func newFlagDoc(flag *pflag.Flag, o flagUsagesOptions, deprecated bool) flagDoc {
+	d := flagDoc{flag: flag}
+	var usage string
+	d.varname, usage = pflag.UnquoteUsage(flag)
+	d.varname = o.sanitizer.escapeLine(flag.Name+" flag type", mapVarname(d.varname))
 
-		required := false
-		if len(flag.Annotations) != 0 {
-			_, required = flag.Annotations[cobra.BashCompOneRequiredFlag]
-		}
-		line += fmt.Sprintf("\n    - %v", required)
-
-		if flag.NoOptDefVal != "" {
-			switch flag.Value.Type() {
-			case stringType:
-				line += fmt.Sprintf("[=%q]", flag.NoOptDefVal)
-			case boolType:
-				if flag.NoOptDefVal != "true" {
-					line += fmt.Sprintf("[=%s]", flag.NoOptDefVal)
-				}
-			case countType:
-				if flag.NoOptDefVal != "+1" {
-					line += fmt.Sprintf("[=%s]", flag.NoOptDefVal)
-				}
-			default:
-				line += fmt.Sprintf("[=%s]", flag.NoOptDefVal)
-			}
-		}
+	if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+		d.names = fmt.Sprintf("-%s, --%s", flag.Shorthand, flag.Name)
+	} else {
+		d.names = "--" + flag.Name
+	}
 
-		if len(flag.Annotations["cobra_annotation_mutually_exclusive"]) != 0 {
-			mutuale := fmt.Sprintf("%v", flag.Annotations["cobra_annotation_mutually_exclusive"])
-			mutuale = strings.ReplaceAll(mutuale, "]", "")
-			mutuale = strings.ReplaceAll(mutuale, "[", "")
-			mutuale = strings.ReplaceAll(mutuale, flag.Name+" ", "")
-			mutuale = strings.ReplaceAll(mutuale, " "+flag.Name, "")
-			mutuale = strings.ReplaceAll(mutuale, " ", ", --")
-			line += "\n    - " + usage + "\n\n      Mutually exclusive with --" + mutuale + "."
-		} else {
-			line += "\n    - " + usage
-		}
-		if !defaultIsZeroValue(flag) {
-			if flag.Value.Type() == stringType {
-				line += fmt.Sprintf(" This value defaults to %q.", flag.DefValue)
-			} else {
-				line += fmt.Sprintf(" This value defaults to %s.", flag.DefValue)
+	if len(flag.Annotations) != 0 {
+		_, d.required = flag.Annotations[cobra.BashCompOneRequiredFlag]
+	}
+
+	if flag.NoOptDefVal != "" {
+		switch flag.Value.Type() {
+		case stringType:
+			d.noOptDefVal = fmt.Sprintf("[=%q]", flag.NoOptDefVal)
+		case boolType:
+			if flag.NoOptDefVal != "true" {
+				d.noOptDefVal = fmt.Sprintf("[=%s]", flag.NoOptDefVal)
 			}
+		case countType:
+			if flag.NoOptDefVal != "+1" {
+				d.noOptDefVal = fmt.Sprintf("[=%s]", flag.NoOptDefVal)
+			}
+		default:
+			d.noOptDefVal = fmt.Sprintf("[=%s]", flag.NoOptDefVal)
 		}
-		if flag.Deprecated != "" {
-			line += fmt.Sprintf(" (DEPRECATED: %s)", flag.Deprecated)
-		}
+	}
 
-		_, _ = fmt.Fprintln(buf, line)
-	})
+	if o.format != nil {
+		usage = o.format(usage)
+	}
+	d.description = usage
+	if len(flag.Annotations[mutuallyExclusiveAnnotation]) != 0 {
+		mutuale := fmt.Sprintf("%v", flag.Annotations[mutuallyExclusiveAnnotation])
+		mutuale = strings.ReplaceAll(mutuale, "]", "")
+		mutuale = strings.ReplaceAll(mutuale, "[", "")
+		mutuale = strings.ReplaceAll(mutuale, flag.Name+" ", "")
+		mutuale = strings.ReplaceAll(mutuale, " "+flag.Name, "")
+		mutuale = strings.ReplaceAll(mutuale, " ", ", --")
+		d.description += "\n\nMutually exclusive with --" + mutuale + "."
+	}
+	if !defaultIsZeroValue(flag) {
+		d.description += fmt.Sprintf(" This value defaults to %s.", o.sanitizer.escapeLine(flag.Name+" flag default", formatDefault(flag)))
+	}
+	if flag.ShorthandDeprecated != "" && o.deprecatedShorthands {
+		d.description += fmt.Sprintf(" (DEPRECATED SHORTHAND -%s: %s)", flag.Shorthand, o.sanitizer.escape(flag.Name+" flag shorthand deprecation", flag.ShorthandDeprecated))
+	}
+	switch {
+	case flag.Deprecated == "":
+	case deprecated:
+		d.description += "\n\n" + o.sanitizer.escape(flag.Name+" flag deprecation", flag.Deprecated)
+	default:
+		d.description += fmt.Sprintf(" (DEPRECATED: %s)", o.sanitizer.escape(flag.Name+" flag deprecation", flag.Deprecated))
+	}
+	d.description += flagVersions(flag, o.sanitizer)
+
+	return d
+}
+
+// row renders the flag as a list-table row.
+func (d flagDoc) row() string {
+	const descriptionIndentation = 6
+	return fmt.Sprintf("  * - %s\n    - %s\n    - %v%s\n    - %s",
+		d.names,
+		d.varname,
+		d.required,
+		d.noOptDefVal,
+		indentContinuation(d.description, strings.Repeat(" ", descriptionIndentation)),
+	)
+}
+
+// directive renders the flag as an option directive.
+func (d flagDoc) directive() string {
+	const descriptionIndentation = 3
+	indent := strings.Repeat(" ", descriptionIndentation)
+	signature := d.names
+	fields := ""
+	if d.varname != "" {
+		signature += " <" + d.varname + ">"
+		fields = indent + ":Type: " + d.varname + "\n"
+	}
+	fields += fmt.Sprintf("%s:Required: %v%s\n", indent, d.required, d.noOptDefVal)
+	return fmt.Sprintf(".. option:: %s\n\n%s\n\n%s", signature, indentString(d.description, indent), fields)
+}
 
+func flagRows(docs []flagDoc) string {
+	buf := new(bytes.Buffer)
+	for _, d := range docs {
+		_, _ = fmt.Fprintln(buf, d.row())
+	}
 	return buf.String()
 }
 
+// DefaultValuer can be implemented by custom pflag.Value types to control
+// how their default value is documented.
+type DefaultValuer interface {
+	// IsZeroDefault reports whether defValue is the zero value of the type,
+	// in which case no default is documented.
+	IsZeroDefault(defValue string) bool
+	// DocDefault returns the human-readable form of defValue.
+	DocDefault(defValue string) string
+}
+
 // defaultIsZeroValue returns true if the default value for this flag represents
 // a zero value.
 func defaultIsZeroValue(f *pflag.Flag) bool {
+	if v, ok := f.Value.(DefaultValuer); ok {
+		return v.IsZeroDefault(f.DefValue)
+	}
+
 	switch f.Value.Type() {
-	case boolType:
-		return f.DefValue == falseValue
+	case boolType, "boolfunc":
+		return f.DefValue == falseValue || f.DefValue == ""
 	case "duration":
 		// Beginning in Go 1.7, duration zero values are "0s"
 		return f.DefValue == "0" || f.DefValue == "0s"
-	case "int", "int8", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "count", "float32", "float64":
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		countType, "float32", "float64":
 		return f.DefValue == "0"
-	case stringType:
+	case stringType, "bytesHex", "bytesBase64", "time", "func":
 		return f.DefValue == ""
 	case "ip", "ipMask", "ipNet":
-		return f.DefValue == nilValue
-	case "intSlice", "stringSlice", "stringArray", "stringToString":
+		return f.DefValue == nilValue || f.DefValue == ""
+	case "boolSlice", "durationSlice",
+		"intSlice", "int32Slice", "int64Slice", "uintSlice",
+		"float32Slice", "float64Slice",
+		"ipSlice", "ipNetSlice",
+		"stringSlice", "stringArray",
+		"stringToString", "stringToInt", "stringToInt64":
 		return f.DefValue == "[]"
 	default:
-		switch f.Value.String() {
-		case falseValue:
-			return true
-		case nilValue:
-			return true
-		case "":
-			return true
-		case "0":
+		switch f.DefValue {
+		case falseValue, nilValue, "", "0", "[]":
 			return true
 		}
 		return false
 	}
 }
 
+// formatDefault returns the default value of the flag as it should be documented.
+func formatDefault(f *pflag.Flag) string {
+	if v, ok := f.Value.(DefaultValuer); ok {
+		return v.DocDefault(f.DefValue)
+	}
+	if f.Value.Type() == stringType {
+		return fmt.Sprintf("%q", f.DefValue)
+	}
+	return f.DefValue
+}
+
 func mapVarname(varname string) string {
 	mapped, found := varnameMap[varname]
 	if !found {
@@ -0,0 +1,117 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type sizeValue struct {
+	v string
+}
+
+func (s *sizeValue) String() string     { return s.v }
+func (s *sizeValue) Set(v string) error { s.v = v; return nil }
+func (*sizeValue) Type() string         { return "size" }
+
+func (*sizeValue) IsZeroDefault(defValue string) bool { return defValue == "0B" }
+func (*sizeValue) DocDefault(defValue string) string  { return defValue + " (bytes)" }
+
+func TestDefaultIsZeroValue(t *testing.T) {
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	f.Bool("bool", false, "")
+	f.BoolSlice("boolSlice", nil, "")
+	f.BytesHex("bytesHex", nil, "")
+	f.BytesBase64("bytesBase64", nil, "")
+	f.Count("count", "")
+	f.Duration("duration", 0, "")
+	f.DurationSlice("durationSlice", nil, "")
+	f.Float32("float32", 0, "")
+	f.Float32Slice("float32Slice", nil, "")
+	f.Float64("float64", 0, "")
+	f.Float64Slice("float64Slice", nil, "")
+	f.Int("int", 0, "")
+	f.Int8("int8", 0, "")
+	f.Int16("int16", 0, "")
+	f.Int32("int32", 0, "")
+	f.Int32Slice("int32Slice", nil, "")
+	f.Int64("int64", 0, "")
+	f.Int64Slice("int64Slice", nil, "")
+	f.IntSlice("intSlice", nil, "")
+	f.IP("ip", nil, "")
+	f.IPSlice("ipSlice", nil, "")
+	f.IPMask("ipMask", nil, "")
+	f.IPNet("ipNet", net.IPNet{}, "")
+	f.IPNetSlice("ipNetSlice", nil, "")
+	f.String("string", "", "")
+	f.StringArray("stringArray", nil, "")
+	f.StringSlice("stringSlice", nil, "")
+	f.StringToInt("stringToInt", nil, "")
+	f.StringToInt64("stringToInt64", nil, "")
+	f.StringToString("stringToString", nil, "")
+	f.Time("time", time.Time{}, nil, "")
+	f.Uint("uint", 0, "")
+	f.Uint8("uint8", 0, "")
+	f.Uint16("uint16", 0, "")
+	f.Uint32("uint32", 0, "")
+	f.Uint64("uint64", 0, "")
+	f.UintSlice("uintSlice", nil, "")
+	f.Var(&sizeValue{v: "0B"}, "size", "")
+
+	f.VisitAll(func(flag *pflag.Flag) {
+		if !defaultIsZeroValue(flag) {
+			t.Errorf("expected %s (%s) with default %q to be a zero value", flag.Name, flag.Value.Type(), flag.DefValue)
+		}
+	})
+}
+
+func TestDefaultIsNotZeroValue(t *testing.T) {
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	f.Int16("int16", 1, "")
+	f.UintSlice("uintSlice", []uint{1}, "")
+	f.DurationSlice("durationSlice", []time.Duration{time.Second}, "")
+	f.Float64Slice("float64Slice", []float64{1.5}, "")
+	f.BytesHex("bytesHex", []byte{1}, "")
+	f.BytesBase64("bytesBase64", []byte{1}, "")
+	f.IPSlice("ipSlice", []net.IP{net.IPv4(127, 0, 0, 1)}, "")
+	f.BoolSlice("boolSlice", []bool{false}, "")
+	f.Var(&sizeValue{v: "1KB"}, "size", "")
+
+	f.VisitAll(func(flag *pflag.Flag) {
+		if defaultIsZeroValue(flag) {
+			t.Errorf("expected %s (%s) with default %q not to be a zero value", flag.Name, flag.Value.Type(), flag.DefValue)
+		}
+	})
+}
+
+func TestFlagUsagesDefaultValuer(t *testing.T) {
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	f.Var(&sizeValue{v: "1KB"}, "size", "max size")
+
+	checkStringContains(t, FlagUsages(f), "max size This value defaults to 1KB (bytes).")
+}
+
+func TestFlagUsagesOptions(t *testing.T) {
+	f := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	f.String("name", "", "Set my_var_ to a|b")
+
+	checkStringContains(t, FlagUsages(f), "Set my_var_ to a|b")
+	checkStringContains(t, FlagUsages(f, WithEscaping()), `Set my_var\_ to a\|b`)
+	checkStringContains(t, FlagUsages(f, WithEscaping(), WithMarkdown()), `Set my_var\_ to a\|b`)
+}
@@ -0,0 +1,442 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reading the date of the checked out commit is done without the git binary,
+// supporting loose objects, pack files (including deltified objects), packed refs and worktrees.
+// The git binary is only used as a last resort, for objects this reader can't find,
+// such as packed objects of SHA-256 repositories or objects of alternate object directories.
+
+const (
+	hashSize           = 20
+	minHashSize        = 2
+	maxSymbolicRefs    = 5
+	packIdxMagic       = 0xff744f63
+	packIdxVersion     = 2
+	packFanoutEntries  = 256
+	packLargeOffsetBit = 0x80000000
+	packOfsDelta       = 6
+	packRefDelta       = 7
+	deltaDefaultCopy   = 0x10000
+	varintMask         = 0x7f
+	varintContinue     = 0x80
+)
+
+var (
+	ErrNotGitRepository = errors.New("not a git repository")
+	ErrGitObjectMissing = errors.New("git object not found")
+	ErrInvalidGitObject = errors.New("invalid git object")
+)
+
+// GitCommitTime returns the committer date of the commit checked out in the git repository containing path.
+func GitCommitTime(path string) (time.Time, error) {
+	gitDir, err := findGitDir(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	commonDir := gitDir
+	if b, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = strings.TrimSpace(string(b))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+	}
+
+	hash, err := resolveGitRef(gitDir, commonDir, "HEAD")
+	if err != nil {
+		return time.Time{}, err
+	}
+	typ, data, err := readGitObject(commonDir, hash)
+	if errors.Is(err, ErrGitObjectMissing) {
+		// last resort, cat-file checks the object is a commit
+		typ = "commit"
+		data, err = catGitCommit(gitDir, hash)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if typ != "commit" {
+		return time.Time{}, fmt.Errorf("%w: %s is a %s", ErrInvalidGitObject, hash, typ)
+	}
+	return committerTime(data)
+}
+
+// findGitDir returns the git directory of the repository containing path.
+func findGitDir(path string) (string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	for {
+		candidate := filepath.Join(dir, ".git")
+		info, err := os.Stat(candidate)
+		if err == nil && info.IsDir() {
+			return candidate, nil
+		}
+		if err == nil {
+			// worktrees and submodules use a file pointing to the git directory
+			b, err := os.ReadFile(candidate)
+			if err != nil {
+				return "", err
+			}
+			gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(b)), "gitdir: ")
+			if !ok {
+				return "", fmt.Errorf("%w: %s", ErrNotGitRepository, candidate)
+			}
+			if !filepath.IsAbs(gitDir) {
+				gitDir = filepath.Join(dir, gitDir)
+			}
+			return gitDir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("%w: %s", ErrNotGitRepository, path)
+		}
+		dir = parent
+	}
+}
+
+// resolveGitRef returns the hash a ref points to, following symbolic refs.
+func resolveGitRef(gitDir, commonDir, ref string) (string, error) {
+	for i := 0; i < maxSymbolicRefs; i++ {
+		value, err := readGitRef(gitDir, commonDir, ref)
+		if err != nil {
+			return "", err
+		}
+		target, symbolic := strings.CutPrefix(value, "ref: ")
+		if !symbolic {
+			return value, nil
+		}
+		ref = target
+	}
+	return "", fmt.Errorf("%w: too many symbolic refs from %s", ErrGitObjectMissing, ref)
+}
+
+func readGitRef(gitDir, commonDir, ref string) (string, error) {
+	for _, dir := range []string{gitDir, commonDir} {
+		if b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(ref))); err == nil {
+			return strings.TrimSpace(string(b)), nil
+		}
+	}
+
+	f, err := os.Open(filepath.Join(commonDir, "packed-refs"))
+	if err != nil {
+		return "", fmt.Errorf("%w: ref %s", ErrGitObjectMissing, ref)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		hash, name, ok := strings.Cut(scanner.Text(), " ")
+		if ok && name == ref {
+			return hash, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%w: ref %s", ErrGitObjectMissing, ref)
+}
+
+// readGitObject returns the type and content of an object, either loose or packed.
+// Only the packs of SHA-1 repositories are read.
+func readGitObject(commonDir, hash string) (string, []byte, error) {
+	sha, err := hex.DecodeString(hash)
+	if err != nil || len(sha) < minHashSize {
+		return "", nil, fmt.Errorf("%w: invalid hash %q", ErrInvalidGitObject, hash)
+	}
+	objects := filepath.Join(commonDir, "objects")
+	if f, err := os.Open(filepath.Join(objects, hash[:2], hash[2:])); err == nil {
+		defer f.Close()
+		return readLooseObject(f)
+	}
+	if len(sha) != hashSize {
+		return "", nil, fmt.Errorf("%w: %s", ErrGitObjectMissing, hash)
+	}
+
+	indexes, err := filepath.Glob(filepath.Join(objects, "pack", "*.idx"))
+	if err != nil {
+		return "", nil, err
+	}
+	sort.Strings(indexes)
+	for _, idx := range indexes {
+		offset, found, err := findPackOffset(idx, sha)
+		if err != nil {
+			return "", nil, err
+		}
+		if !found {
+			continue
+		}
+		pack, err := os.Open(strings.TrimSuffix(idx, ".idx") + ".pack")
+		if err != nil {
+			return "", nil, err
+		}
+		defer pack.Close()
+		return readPackObject(commonDir, pack, offset)
+	}
+	return "", nil, fmt.Errorf("%w: %s", ErrGitObjectMissing, hash)
+}
+
+// catGitCommit reads a commit object with the git binary.
+func catGitCommit(gitDir, hash string) ([]byte, error) {
+	out, err := exec.Command("git", "--git-dir", gitDir, "cat-file", "commit", hash).Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrGitObjectMissing, hash, err)
+	}
+	return out, nil
+}
+
+func readLooseObject(r io.Reader) (string, []byte, error) {
+	z, err := zlib.NewReader(r)
+	if err != nil {
+		return "", nil, err
+	}
+	defer z.Close()
+	b, err := io.ReadAll(z)
+	if err != nil {
+		return "", nil, err
+	}
+	header, data, ok := bytes.Cut(b, []byte{0})
+	if !ok {
+		return "", nil, ErrInvalidGitObject
+	}
+	typ, _, _ := strings.Cut(string(header), " ")
+	return typ, data, nil
+}
+
+// findPackOffset looks up the offset of an object in a version 2 pack index.
+func findPackOffset(idxPath string, sha []byte) (int64, bool, error) {
+	idx, err := os.ReadFile(idxPath)
+	if err != nil {
+		return 0, false, err
+	}
+	const headerSize = 8
+	fanoutEnd := headerSize + 4*packFanoutEntries
+	if len(idx) < fanoutEnd || binary.BigEndian.Uint32(idx) != packIdxMagic || binary.BigEndian.Uint32(idx[4:]) != packIdxVersion {
+		return 0, false, fmt.Errorf("%w: unsupported pack index %s", ErrInvalidGitObject, idxPath)
+	}
+	fanout := func(i int) int {
+		if i < 0 {
+			return 0
+		}
+		return int(binary.BigEndian.Uint32(idx[headerSize+4*i:]))
+	}
+	count := fanout(packFanoutEntries - 1)
+	namesEnd := fanoutEnd + hashSize*count
+	offsetsStart := namesEnd + 4*count
+	if len(idx) < offsetsStart+4*count {
+		return 0, false, fmt.Errorf("%w: truncated pack index %s", ErrInvalidGitObject, idxPath)
+	}
+
+	lo, hi := fanout(int(sha[0])-1), fanout(int(sha[0]))
+	i := lo + sort.Search(hi-lo, func(i int) bool {
+		return bytes.Compare(idx[fanoutEnd+hashSize*(lo+i):fanoutEnd+hashSize*(lo+i+1)], sha) >= 0
+	})
+	if i >= hi || !bytes.Equal(idx[fanoutEnd+hashSize*i:fanoutEnd+hashSize*(i+1)], sha) {
+		return 0, false, nil
+	}
+
+	offset := binary.BigEndian.Uint32(idx[offsetsStart+4*i:])
+	if offset&packLargeOffsetBit == 0 {
+		return int64(offset), true, nil
+	}
+	large := offsetsStart + 4*count + 8*int(offset&^packLargeOffsetBit)
+	if len(idx) < large+8 {
+		return 0, false, fmt.Errorf("%w: truncated pack index %s", ErrInvalidGitObject, idxPath)
+	}
+	return int64(binary.BigEndian.Uint64(idx[large:])), true, nil
+}
+
+// readPackObject reads the object at offset in a pack, resolving deltas.
+func readPackObject(commonDir string, pack io.ReaderAt, offset int64) (string, []byte, error) {
+	pos := offset
+	next := func() (byte, error) {
+		b := make([]byte, 1)
+		if _, err := pack.ReadAt(b, pos); err != nil {
+			return 0, err
+		}
+		pos++
+		return b[0], nil
+	}
+
+	c, err := next()
+	if err != nil {
+		return "", nil, err
+	}
+	const typeShift, typeMask = 4, 7
+	typ := int(c>>typeShift) & typeMask
+	for c&varintContinue != 0 {
+		if c, err = next(); err != nil {
+			return "", nil, err
+		}
+	}
+
+	var base func() (string, []byte, error)
+	switch typ {
+	case packOfsDelta:
+		c, err := next()
+		if err != nil {
+			return "", nil, err
+		}
+		distance := int64(c & varintMask)
+		for c&varintContinue != 0 {
+			if c, err = next(); err != nil {
+				return "", nil, err
+			}
+			distance = (distance+1)<<7 | int64(c&varintMask)
+		}
+		base = func() (string, []byte, error) { return readPackObject(commonDir, pack, offset-distance) }
+	case packRefDelta:
+		sha := make([]byte, hashSize)
+		if _, err := pack.ReadAt(sha, pos); err != nil {
+			return "", nil, err
+		}
+		pos += hashSize
+		base = func() (string, []byte, error) { return readGitObject(commonDir, hex.EncodeToString(sha)) }
+	}
+
+	z, err := zlib.NewReader(io.NewSectionReader(pack, pos, 1<<62))
+	if err != nil {
+		return "", nil, err
+	}
+	defer z.Close()
+	data, err := io.ReadAll(z)
+	if err != nil {
+		return "", nil, err
+	}
+	if base == nil {
+		types := map[int]string{1: "commit", 2: "tree", 3: "blob", 4: "tag"}
+		return types[typ], data, nil
+	}
+	baseType, baseData, err := base()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err = applyGitDelta(baseData, data)
+	return baseType, data, err
+}
+
+// applyGitDelta rebuilds an object from its base and a delta of copy and insert instructions.
+func applyGitDelta(base, delta []byte) ([]byte, error) {
+	errInvalid := fmt.Errorf("%w: invalid delta", ErrInvalidGitObject)
+	readSize := func() (int, bool) {
+		size, shift := 0, 0
+		for len(delta) > 0 {
+			c := delta[0]
+			delta = delta[1:]
+			size |= int(c&varintMask) << shift
+			shift += 7
+			if c&varintContinue == 0 {
+				return size, true
+			}
+		}
+		return 0, false
+	}
+	if _, ok := readSize(); !ok {
+		return nil, errInvalid
+	}
+	size, ok := readSize()
+	if !ok {
+		return nil, errInvalid
+	}
+
+	out := make([]byte, 0, size)
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+		switch {
+		case op&varintContinue != 0:
+			// copy from base, the bits of op tell which offset and size bytes are present
+			var values [7]int
+			for i := range values {
+				if op&(1<<i) == 0 {
+					continue
+				}
+				if len(delta) == 0 {
+					return nil, errInvalid
+				}
+				values[i] = int(delta[0])
+				delta = delta[1:]
+			}
+			start := values[0] | values[1]<<8 | values[2]<<16 | values[3]<<24
+			n := values[4] | values[5]<<8 | values[6]<<16
+			if n == 0 {
+				n = deltaDefaultCopy
+			}
+			if start+n > len(base) {
+				return nil, errInvalid
+			}
+			out = append(out, base[start:start+n]...)
+		case op != 0:
+			// insert the next op bytes
+			if int(op) > len(delta) {
+				return nil, errInvalid
+			}
+			out = append(out, delta[:op]...)
+			delta = delta[op:]
+		default:
+			return nil, errInvalid
+		}
+	}
+	if len(out) != size {
+		return nil, errInvalid
+	}
+	return out, nil
+}
+
+// committerTime parses the committer date of a commit object.
+func committerTime(commit []byte) (time.Time, error) {
+	for _, line := range strings.Split(string(commit), "\n") {
+		if line == "" {
+			break
+		}
+		rest, ok := strings.CutPrefix(line, "committer ")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		const dateFields = 2
+		if len(fields) < dateFields {
+			break
+		}
+		seconds, err := strconv.ParseInt(fields[len(fields)-2], 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: invalid committer date: %w", ErrInvalidGitObject, err)
+		}
+		// fall back to UTC for unparsable zones
+		if zone, err := time.Parse("-0700", fields[len(fields)-1]); err == nil {
+			return time.Unix(seconds, 0).In(zone.Location()), nil
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: commit without committer", ErrInvalidGitObject)
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGitCommitTime(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_COMMITTER_DATE=2025-03-05T17:00:00+01:00",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("test"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "test")
+
+	subdir := filepath.Join(dir, "docs")
+	if err := os.Mkdir(subdir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	expected := time.Date(2025, 3, 5, 16, 0, 0, 0, time.UTC)
+
+	check := func(t *testing.T) {
+		t.Helper()
+		got, err := GitCommitTime(subdir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(expected) {
+			t.Fatalf("expected %s, got %s", expected, got)
+		}
+	}
+
+	t.Run("loose", check)
+	t.Run("packed", func(t *testing.T) {
+		git("gc", "-q")
+		// the packs are read without the git binary
+		t.Setenv("PATH", "")
+		check(t)
+	})
+	t.Run("not a repository", func(t *testing.T) {
+		if _, err := GitCommitTime(string(filepath.Separator)); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+	t.Run("option", func(t *testing.T) {
+		options := newGenDocsOptions([]GenDocsOption{WithGitCommitTime(subdir)})
+		if got := options.footer(); got != "*Auto generated by cobra2snooty on 5-Mar-2025*" {
+			t.Fatalf("unexpected footer: %s", got)
+		}
+		err := GenDocs(Root(), io.Discard, WithGitCommitTime(string(filepath.Separator)))
+		if !errors.Is(err, ErrNotGitRepository) {
+			t.Fatalf("expected %v, got: %v", ErrNotGitRepository, err)
+		}
+		err = GenTreeDocs(Root(), t.TempDir(), WithGitCommitTime(string(filepath.Separator)))
+		if !errors.Is(err, ErrNotGitRepository) {
+			t.Fatalf("expected %v, got: %v", ErrNotGitRepository, err)
+		}
+	})
+}
+
+func TestReadPackObject(t *testing.T) {
+	compress := func(level int, data []byte) []byte {
+		buf := new(bytes.Buffer)
+		z, err := zlib.NewWriterLevel(buf, level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := z.Write(data); err != nil {
+			t.Fatal(err)
+		}
+		if err := z.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	header := func(typ, size int) []byte {
+		b := []byte{byte(typ<<4 | size&0x0f)}
+		for size >>= 4; size > 0; size >>= 7 {
+			b[len(b)-1] |= varintContinue
+			b = append(b, byte(size&varintMask))
+		}
+		return b
+	}
+	objectID := func(typ string, data []byte) []byte {
+		sum := sha1.Sum(append([]byte(fmt.Sprintf("%s %d\x00", typ, len(data))), data...))
+		return sum[:]
+	}
+
+	base := []byte("hello world")
+	baseID := objectID("blob", base)
+	// copy 6 bytes from offset 0, insert "git"
+	ofsDelta := []byte{11, 9, 0x80 | 0x10, 6, 3, 'g', 'i', 't'}
+	// copy 6 bytes from offset 0, insert "there"
+	refDelta := []byte{11, 11, 0x80 | 0x10, 6, 5, 't', 'h', 'e', 'r', 'e'}
+
+	pack := []byte("PACK\x00\x00\x00\x02\x00\x00\x00\x04")
+	type entry struct {
+		id     []byte
+		offset int
+		want   string
+	}
+	var entries []entry
+	add := func(id []byte, want string, object ...[]byte) {
+		entries = append(entries, entry{id: id, offset: len(pack), want: want})
+		for _, b := range object {
+			pack = append(pack, b...)
+		}
+	}
+	add(baseID, "hello world", header(3, len(base)), compress(zlib.DefaultCompression, base))
+	// an uncompressed filler so that the distance of the ofs-delta takes several bytes
+	filler := bytes.Repeat([]byte{'x'}, 200)
+	add(objectID("blob", filler), string(filler), header(3, len(filler)), compress(flate.NoCompression, filler))
+	distance := len(pack) - entries[0].offset
+	encoded := []byte{byte(distance & varintMask)}
+	for n := distance >> 7; n > 0; n >>= 7 {
+		n--
+		encoded = append([]byte{byte(varintContinue | n&varintMask)}, encoded...)
+	}
+	if len(encoded) < 2 {
+		t.Fatalf("expected a multi-byte distance, got %d", distance)
+	}
+	add(objectID("blob", []byte("hello git")), "hello git", header(packOfsDelta, len(ofsDelta)), encoded, compress(zlib.DefaultCompression, ofsDelta))
+	add(objectID("blob", []byte("hello there")), "hello there", header(packRefDelta, len(refDelta)), baseID, compress(zlib.DefaultCompression, refDelta))
+
+	sorted := slices.Clone(entries)
+	slices.SortFunc(sorted, func(a, b entry) int { return bytes.Compare(a.id, b.id) })
+	idx := binary.BigEndian.AppendUint32(nil, packIdxMagic)
+	idx = binary.BigEndian.AppendUint32(idx, packIdxVersion)
+	for i := range packFanoutEntries {
+		n := 0
+		for _, e := range sorted {
+			if int(e.id[0]) <= i {
+				n++
+			}
+		}
+		idx = binary.BigEndian.AppendUint32(idx, uint32(n))
+	}
+	for _, e := range sorted {
+		idx = append(idx, e.id...)
+	}
+	for range sorted {
+		idx = binary.BigEndian.AppendUint32(idx, 0)
+	}
+	// the last object is stored in the large offset table
+	var large []byte
+	for _, e := range sorted {
+		if bytes.Equal(e.id, entries[len(entries)-1].id) {
+			idx = binary.BigEndian.AppendUint32(idx, packLargeOffsetBit)
+			large = binary.BigEndian.AppendUint64(large, uint64(e.offset))
+			continue
+		}
+		idx = binary.BigEndian.AppendUint32(idx, uint32(e.offset))
+	}
+	idx = append(idx, large...)
+
+	dir := t.TempDir()
+	packDir := filepath.Join(dir, "objects", "pack")
+	if err := os.MkdirAll(packDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(packDir, "pack-test.idx"), idx, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(packDir, "pack-test.pack"), pack, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range entries {
+		typ, data, err := readGitObject(dir, hex.EncodeToString(e.id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if typ != "blob" || string(data) != e.want {
+			t.Errorf("expected blob %q, got %s %q", e.want, typ, data)
+		}
+	}
+	if _, _, err := readGitObject(dir, strings.Repeat("0", 2*hashSize)); !errors.Is(err, ErrGitObjectMissing) {
+		t.Errorf("expected %v, got: %v", ErrGitObjectMissing, err)
+	}
+}
+
+func TestApplyGitDelta(t *testing.T) {
+	base := []byte("hello world")
+	// source size 11, target size 9, copy 6 bytes from offset 0, insert "git"
+	delta := []byte{11, 9, 0x80 | 0x10, 6, 3, 'g', 'i', 't'}
+	got, err := applyGitDelta(base, delta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, []byte("hello git")) {
+		t.Fatalf("expected %q, got %q", "hello git", got)
+	}
+	if _, err := applyGitDelta(base, []byte{11, 9, 0x80 | 0x10, 20}); err == nil {
+		t.Fatal("expected an error for an out of range copy")
+	}
+}
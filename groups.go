@@ -0,0 +1,67 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const ungroupedTitle = "Additional Commands"
+
+// commandGroup is a titled list of commands, see cobra.Group.
+type commandGroup struct {
+	title    string
+	commands []*cobra.Command
+}
+
+// commandGroups returns the subcommands of cmd accepted by include, sorted by name and grouped by
+// cobra.Group in the order of cmd.Groups(). Ungrouped commands come last, titled "Additional Commands"
+// when there are groups.
+func commandGroups(cmd *cobra.Command, include func(*cobra.Command) bool) []commandGroup {
+	children := cmd.Commands()
+	sort.Sort(byName(children))
+
+	groups := make([]commandGroup, 0, len(cmd.Groups())+1)
+	index := map[string]int{}
+	for _, g := range cmd.Groups() {
+		index[g.ID] = len(groups)
+		groups = append(groups, commandGroup{title: strings.TrimSuffix(strings.TrimSpace(g.Title), ":")})
+	}
+	ungrouped := commandGroup{}
+	for _, c := range children {
+		if !include(c) {
+			continue
+		}
+		if i, ok := index[c.GroupID]; ok {
+			groups[i].commands = append(groups[i].commands, c)
+		} else {
+			ungrouped.commands = append(ungrouped.commands, c)
+		}
+	}
+	if len(groups) > 0 {
+		ungrouped.title = ungroupedTitle
+	}
+
+	result := make([]commandGroup, 0, len(groups)+1)
+	for _, g := range append(groups, ungrouped) {
+		if len(g.commands) > 0 {
+			result = append(result, g)
+		}
+	}
+	return result
+}
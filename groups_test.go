@@ -0,0 +1,61 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGenDocsCommandGroups(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := GenDocs(groupedTree(), buf, WithoutDate()); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+
+	checkStringContains(t, output, "Related Commands\n----------------\n\n"+
+		"Management Commands\n~~~~~~~~~~~~~~~~~~~\n\n"+
+		"* :ref:`mycli-backups` - Manage backups\n"+
+		"* :ref:`mycli-clusters` - Manage clusters\n\n"+
+		"Authentication Commands\n~~~~~~~~~~~~~~~~~~~~~~~\n\n"+
+		"* :ref:`mycli-login` - Log in\n\n"+
+		"Additional Commands\n~~~~~~~~~~~~~~~~~~~\n\n"+
+		"* :ref:`mycli-version` - Print the version\n\n")
+	checkStringContains(t, output, `.. toctree::
+   :titlesonly:
+
+   backups </command/mycli-backups>
+   clusters </command/mycli-clusters>
+   login </command/mycli-login>
+   version </command/mycli-version>
+`)
+}
+
+func TestGenDocsWithoutCommandGroups(t *testing.T) {
+	root := groupedTree()
+	clusters, _, err := root.Find([]string{"clusters"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if err := GenDocs(clusters, buf, WithoutDate()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringContains(t, buf.String(), "Related Commands\n----------------\n\n"+
+		"* :ref:`mycli-clusters-create` - Create a cluster\n"+
+		"* :ref:`mycli-clusters-list` - List clusters\n\n")
+	checkStringOmits(t, buf.String(), ungroupedTitle)
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+const indexTitleSuffix = " Command Reference"
+
+// WithIndexPage makes GenTreeDocs also write the landing page of the command reference to filename,
+// see GenIndexDocs.
+func WithIndexPage(filename string) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.indexFilename = filename
+	}
+}
+
+// WithGroupedIndex groups the top-level commands of the index page by cobra.Group,
+// in the order the groups were added to the root command.
+func WithGroupedIndex() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.groupedIndex = true
+	}
+}
+
+// GenIndexDocs creates the landing page of the command reference of root: a nested list of all
+// the documented commands sorted by name, with their short description, followed by a toctree
+// of all their pages.
+func GenIndexDocs(root *cobra.Command, w io.Writer, genDocOptions ...GenDocsOption) error {
+	options := newGenDocsOptions(genDocOptions)
+	if options.err != nil {
+		return options.err
+	}
+
+	buf := new(bytes.Buffer)
+	name := root.CommandPath()
+	title := options.sanitizer.escapeLine(name+" command path", name) + indexTitleSuffix
+	buf.WriteString(".. _" + options.label(root) + "-command-reference:\n\n")
+	buf.WriteString(options.pageStyle.title(title))
+	buf.WriteString(options.pageStyle.preamble())
+	_, _ = fmt.Fprintf(buf, "\n:ref:`%s` - %s\n\n", options.label(root), options.formatText(root, root.Short))
+
+	if options.groupedIndex {
+		for _, group := range commandGroups(root, options.isDocumented) {
+			if group.title != "" {
+				buf.WriteString(options.pageStyle.section(group.title) + "\n")
+			}
+			printIndexList(buf, group.commands, "", options)
+		}
+	} else {
+		printIndexList(buf, documentedChildren(root, options), "", options)
+	}
+
+	buf.WriteString(tocHeader)
+	buf.WriteString("\n")
+	printIndexToctree(buf, root, options)
+	buf.WriteString("\n")
+
+	if err := options.sanitizer.err(); err != nil {
+		return err
+	}
+	if !root.DisableAutoGenTag {
+		buf.WriteString(options.footer() + "\n")
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// documentedChildren returns the documented subcommands of cmd sorted by name.
+func documentedChildren(cmd *cobra.Command, options *GenDocsOptions) []*cobra.Command {
+	var children []*cobra.Command
+	for _, c := range cmd.Commands() {
+		if options.isDocumented(c) {
+			children = append(children, c)
+		}
+	}
+	sort.Sort(byName(children))
+	return children
+}
+
+func printIndexList(buf *bytes.Buffer, commands []*cobra.Command, indent string, options *GenDocsOptions) {
+	for _, c := range commands {
+		_, _ = fmt.Fprintf(buf, "%s* :ref:`%s` - %s\n\n", indent, options.label(c), options.formatText(c, c.Short))
+		printIndexList(buf, documentedChildren(c, options), indent+"  ", options)
+	}
+}
+
+func printIndexToctree(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) {
+	_, _ = fmt.Fprintf(buf, "   %s </command/%s>\n", cmd.CommandPath(), commandRef(cmd))
+	for _, c := range documentedChildren(cmd, options) {
+		printIndexToctree(buf, c, options)
+	}
+}
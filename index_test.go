@@ -0,0 +1,82 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+	"github.com/spf13/cobra"
+)
+
+func groupedTree() *cobra.Command {
+	root := &cobra.Command{Use: "mycli", Short: "Manage your clusters"}
+	root.AddGroup(
+		&cobra.Group{ID: "mgmt", Title: "Management Commands:"},
+		&cobra.Group{ID: "auth", Title: "Authentication Commands:"},
+	)
+	clusters := &cobra.Command{Use: "clusters", Short: "Manage clusters", GroupID: "mgmt"}
+	clusters.AddCommand(
+		&cobra.Command{Use: "list", Short: "List clusters", Run: emptyRun},
+		&cobra.Command{Use: "create", Short: "Create a cluster", Run: emptyRun},
+	)
+	root.AddCommand(
+		clusters,
+		&cobra.Command{Use: "backups", Short: "Manage backups", GroupID: "mgmt", Run: emptyRun},
+		&cobra.Command{Use: "login", Short: "Log in", GroupID: "auth", Run: emptyRun},
+		&cobra.Command{Use: "version", Short: "Print the version", Run: emptyRun},
+		&cobra.Command{Use: "secret", Short: "Hidden command", Hidden: true, Run: emptyRun},
+	)
+	return root
+}
+
+func TestGenIndexDocsSnapshots(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []GenDocsOption
+	}{
+		{name: "index", options: []GenDocsOption{WithoutDate()}},
+		{name: "grouped_index", options: []GenDocsOption{WithoutDate(), WithGroupedIndex()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshotter := cupaloy.New(cupaloy.SnapshotFileExtension(".txt"))
+			buf := new(bytes.Buffer)
+			if err := GenIndexDocs(groupedTree(), buf, tt.options...); err != nil {
+				t.Fatalf("GenIndexDocs() error = %v", err)
+			}
+			if err := snapshotter.SnapshotWithName(tt.name, buf.String()); err != nil {
+				t.Errorf("Snapshot comparison failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestGenTreeDocsIndexPage(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(t.TempDir(), "command-reference.txt")
+	if err := GenTreeDocs(groupedTree(), dir, WithoutDate(), WithIndexPage(filename)); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkStringContains(t, string(b), "   mycli clusters list </command/mycli-clusters-list>\n")
+	checkStringOmits(t, string(b), "mycli-secret")
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// inline literals, roles and interpreted text, e.g. ``code``, :ref:`label`, `text`_.
+	inlineMarkupRegex = regexp.MustCompile("(?::[\\w:+.-]+:)?``[^`]+``|(?::[\\w:+.-]+:)?`[^`]+`_{0,2}")
+	flagMentionRegex  = regexp.MustCompile(`--[A-Za-z0-9][\w-]*`)
+	// directives whose content is literal text, e.g. .. code-block:: sh
+	literalDirectiveRegex = regexp.MustCompile(`^\.\. (code-block|code|sourcecode|literalinclude|io-code-block|input|output)::`)
+)
+
+// WithAutoLinks converts mentions of commands of the tree and of flags of the current command
+// found in Short, Long and flag usage texts into cross-references.
+// Commands become :ref: links, flags become :option: roles when rendered with WithOptionDirectives
+// or inline literals otherwise. Text in code blocks and existing inline markup is left untouched.
+func WithAutoLinks() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.autoLinks = true
+	}
+}
+
+// withAutoLinker makes the pages share linker instead of each building its own.
+func withAutoLinker(linker *autoLinker) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.linker = linker
+	}
+}
+
+type autoLinker struct {
+	commands    *regexp.Regexp
+	optionRoles bool
+	style       PageStyle
+}
+
+func newAutoLinker(root *cobra.Command, options *GenDocsOptions) *autoLinker {
+	var paths []string
+	var visit func(c *cobra.Command)
+	visit = func(c *cobra.Command) {
+		for _, child := range c.Commands() {
+			if !options.isDocumented(child) {
+				continue
+			}
+			paths = append(paths, regexp.QuoteMeta(child.CommandPath()))
+			visit(child)
+		}
+	}
+	visit(root)
+
+	l := &autoLinker{optionRoles: options.flagUsages.directives, style: options.pageStyle}
+	if len(paths) == 0 {
+		return l
+	}
+	// longest paths first, so "mycli auth login" wins over "mycli auth"
+	sort.Slice(paths, func(i, j int) bool { return len(paths[i]) > len(paths[j]) })
+	l.commands = regexp.MustCompile(strings.Join(paths, "|"))
+	return l
+}
+
+// link converts the command and flag mentions of text, skipping code blocks.
+func (l *autoLinker) link(cmd *cobra.Command, text string) string {
+	lines := strings.Split(text, "\n")
+	blockIndent := -1
+	fenced := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if strings.HasPrefix(trimmed, "```") {
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			continue
+		}
+		if blockIndent >= 0 {
+			if trimmed == "" || indent > blockIndent {
+				continue
+			}
+			blockIndent = -1
+		}
+		if literalDirectiveRegex.MatchString(trimmed) {
+			blockIndent = indent
+			continue
+		}
+		lines[i] = l.linkLine(cmd, line)
+		if strings.HasSuffix(trimmed, "::") {
+			blockIndent = indent
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// linkLine converts the mentions of a single line, skipping existing inline markup.
+func (l *autoLinker) linkLine(cmd *cobra.Command, line string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range inlineMarkupRegex.FindAllStringIndex(line, -1) {
+		b.WriteString(l.linkText(cmd, line[last:loc[0]]))
+		b.WriteString(line[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(l.linkText(cmd, line[last:]))
+	return b.String()
+}
+
+func (l *autoLinker) linkText(cmd *cobra.Command, text string) string {
+	if l.commands != nil {
+		text = replaceWords(text, l.commands, func(path string) string {
+			if path == cmd.CommandPath() {
+				return path
+			}
+			return fmt.Sprintf(":ref:`%s <%s>`", path, l.style.label(path))
+		})
+	}
+	return replaceWords(text, flagMentionRegex, func(mention string) string {
+		name := strings.TrimPrefix(mention, "--")
+		if cmd.Flags().Lookup(name) == nil && cmd.InheritedFlags().Lookup(name) == nil {
+			return mention
+		}
+		if l.optionRoles {
+			return l.style.FlagRef(cmd, name)
+		}
+		return "``" + mention + "``"
+	})
+}
+
+// replaceWords replaces the matches of re that are not part of a longer word.
+func replaceWords(text string, re *regexp.Regexp, replace func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		if loc[0] > 0 && isWordChar(text[loc[0]-1]) || loc[1] < len(text) && isWordChar(text[loc[1]]) {
+			continue
+		}
+		b.WriteString(text[last:loc[0]])
+		b.WriteString(replace(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func isWordChar(c byte) bool {
+	return c == '-' || c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
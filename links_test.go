@@ -0,0 +1,126 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestAutoLinker(t *testing.T) {
+	Root()
+	tests := []struct {
+		name        string
+		text        string
+		optionRoles bool
+		expected    string
+	}{
+		{
+			name:     "command",
+			text:     "run root echo echosub first",
+			expected: "run :ref:`root echo echosub <root-echo-echosub>` first",
+		},
+		{
+			name:     "longest command wins",
+			text:     "see root echo.",
+			expected: "see :ref:`root echo <root-echo>`.",
+		},
+		{
+			name:     "partial word",
+			text:     "root echoes",
+			expected: "root echoes",
+		},
+		{
+			name:     "current command",
+			text:     "root echo times",
+			expected: "root echo times",
+		},
+		{
+			name:     "flag",
+			text:     "use --inttwo or --unknown",
+			expected: "use ``--inttwo`` or --unknown",
+		},
+		{
+			name:        "inherited flag as option",
+			text:        "use --rootflag",
+			optionRoles: true,
+			expected:    "use :option:`root echo times --rootflag`",
+		},
+		{
+			name:     "existing markup",
+			text:     "use ``root echo --inttwo`` and :ref:`root echo <root-echo>`",
+			expected: "use ``root echo --inttwo`` and :ref:`root echo <root-echo>`",
+		},
+		{
+			name:     "code block",
+			text:     "Example::\n\n   root echo --inttwo\n\nthen root echo",
+			expected: "Example::\n\n   root echo --inttwo\n\nthen :ref:`root echo <root-echo>`",
+		},
+		{
+			name:     "code-block directive",
+			text:     ".. code-block:: sh\n\n   root echo\nroot echo",
+			expected: ".. code-block:: sh\n\n   root echo\n:ref:`root echo <root-echo>`",
+		},
+	}
+
+	times, _, err := Root().Find([]string{"echo", "times"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := newGenDocsOptions(nil)
+			options.flagUsages.directives = tt.optionRoles
+			l := newAutoLinker(Root(), options)
+			if got := l.link(times, tt.text); got != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenTreeDocsSharesAutoLinker(t *testing.T) {
+	root := &cobra.Command{Use: "mycli", Short: "See mycli echo."}
+	root.AddCommand(&cobra.Command{Use: "echo", Short: "Echo, see mycli.", Run: emptyRun})
+
+	var pages []*GenDocsOptions
+	spy := func(options *GenDocsOptions) {
+		pages = append(pages, options)
+	}
+	dir := t.TempDir()
+	if err := GenTreeDocs(root, dir, WithoutDate(), WithAutoLinks(), spy); err != nil {
+		t.Fatal(err)
+	}
+	var linkers []*autoLinker
+	for _, options := range pages {
+		if options.linker != nil {
+			linkers = append(linkers, options.linker)
+		}
+	}
+	if len(linkers) != 2 {
+		t.Fatalf("expected 2 pages using a linker, got %d", len(linkers))
+	}
+	if linkers[0] != linkers[1] {
+		t.Fatal("expected the pages to share the same linker")
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "mycli.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkStringContains(t, string(b), "See :ref:`mycli echo <mycli-echo>`.")
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"regexp"
+	"strings"
+)
+
+const codeBlockIndentation = "   "
+
+var (
+	mdListItemRegex   = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+`)
+	mdHeadingRegex    = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*$`)
+	mdCodeSpanRegex   = regexp.MustCompile("``\\s*(.+?)\\s*``|`([^`]+)`")
+	mdLinkRegex       = regexp.MustCompile(`\[([^\]]+)]\(([^)\s]+)\)`)
+	mdStrongRegex     = regexp.MustCompile(`__([^_]+)__`)
+	mdEmphasisRegex   = regexp.MustCompile(`_([^_]+)_`)
+	mdBulletListRegex = regexp.MustCompile(`^(\s*)[*+]\s+`)
+)
+
+// WithMarkdown converts the Markdown constructs commonly used in help text
+// (code spans, bold, emphasis, lists, links, headings and fenced code blocks)
+// to reStructuredText in Short, Long, argument descriptions and flag usages.
+func WithMarkdown() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.markdown = true
+	}
+}
+
+// MarkdownToRST converts the common Markdown constructs of text to reStructuredText.
+func MarkdownToRST(text string) string {
+	return markdownToRST(text, func(text string) string { return text })
+}
+
+// markdownToRST converts text like MarkdownToRST, applying escape to the text outside of the converted markup.
+func markdownToRST(text string, escape func(string) string) string {
+	lines := strings.Split(text, "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	inList := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			if inFence {
+				inFence = false
+				out = append(out, "")
+				continue
+			}
+			inFence = true
+			out = ensureBlankLine(out)
+			directive := ".. code-block::"
+			if lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```")); lang != "" {
+				directive += " " + lang
+			}
+			out = append(out, directive, "")
+			continue
+		}
+		if inFence {
+			if trimmed == "" {
+				out = append(out, "")
+			} else {
+				out = append(out, codeBlockIndentation+line)
+			}
+			continue
+		}
+
+		isListItem := mdListItemRegex.MatchString(line)
+		switch {
+		case isListItem && !inList:
+			out = ensureBlankLine(out)
+		case !isListItem && inList && trimmed != "" && !strings.HasPrefix(line, " "):
+			// a paragraph right after a list must be separated by a blank line
+			out = ensureBlankLine(out)
+		}
+		if isListItem {
+			line = mdBulletListRegex.ReplaceAllString(line, "$1- ")
+		}
+		inList = isListItem || (inList && trimmed != "")
+
+		if m := mdHeadingRegex.FindStringSubmatch(trimmed); m != nil {
+			out = append(out, "**"+markdownInlineToRST(m[1], escape)+"**")
+			continue
+		}
+		out = append(out, markdownInlineToRST(line, escape))
+	}
+	if inFence {
+		out = append(out, "")
+	}
+	return strings.Join(out, "\n")
+}
+
+// markdownInlineToRST converts the inline constructs of a single line, leaving code spans verbatim.
+func markdownInlineToRST(line string, escape func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range mdCodeSpanRegex.FindAllStringSubmatchIndex(line, -1) {
+		if loc[0] > 0 && line[loc[0]-1] == ':' {
+			// already an RST role, e.g. :ref:`label`
+			continue
+		}
+		b.WriteString(markdownTextToRST(line[last:loc[0]], escape))
+		code := ""
+		if loc[2] >= 0 {
+			code = line[loc[2]:loc[3]]
+		} else {
+			code = strings.TrimSpace(line[loc[4]:loc[5]])
+		}
+		b.WriteString("``" + code + "``")
+		last = loc[1]
+	}
+	b.WriteString(markdownTextToRST(line[last:], escape))
+	return b.String()
+}
+
+func markdownTextToRST(text string, escape func(string) string) string {
+	return replaceMatches(mdLinkRegex, text, func(m []string) string {
+		return "`" + escape(m[1]) + " <" + m[2] + ">`__"
+	}, func(text string) string {
+		return replaceMatches(mdStrongRegex, text, func(m []string) string {
+			return "**" + escape(m[1]) + "**"
+		}, func(text string) string {
+			return convertEmphasis(text, escape)
+		})
+	})
+}
+
+// convertEmphasis converts the _emphasis_ delimited by non-word characters, checking the delimiters
+// without consuming them so adjacent emphasis are all converted.
+func convertEmphasis(text string, escape func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for start := 0; start < len(text); {
+		loc := mdEmphasisRegex.FindStringSubmatchIndex(text[start:])
+		if loc == nil {
+			break
+		}
+		from, to := start+loc[0], start+loc[1]
+		if from > 0 && isMarkdownWordChar(text[from-1]) || to < len(text) && isMarkdownWordChar(text[to]) {
+			start = from + 1
+			continue
+		}
+		b.WriteString(escape(text[last:from]))
+		b.WriteString("*" + escape(text[start+loc[2]:start+loc[3]]) + "*")
+		last, start = to, to
+	}
+	b.WriteString(escape(text[last:]))
+	return b.String()
+}
+
+// isMarkdownWordChar reports whether c is a word character, as \w.
+func isMarkdownWordChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// replaceMatches replaces the matches of re in text with convert and the text between them with rest.
+func replaceMatches(re *regexp.Regexp, text string, convert func(submatches []string) string, rest func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(rest(text[last:loc[0]]))
+		submatches := make([]string, len(loc)/2)
+		for i := range submatches {
+			if loc[2*i] >= 0 {
+				submatches[i] = text[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		b.WriteString(convert(submatches))
+		last = loc[1]
+	}
+	b.WriteString(rest(text[last:]))
+	return b.String()
+}
+
+// ensureBlankLine appends a blank line unless lines is empty or already ends with one.
+func ensureBlankLine(lines []string) []string {
+	if len(lines) == 0 || strings.TrimSpace(lines[len(lines)-1]) == "" {
+		return lines
+	}
+	return append(lines, "")
+}
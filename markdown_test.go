@@ -0,0 +1,102 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestMarkdownToRST(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{
+			name:     "code span",
+			markdown: "use `mycli auth login` first",
+			expected: "use ``mycli auth login`` first",
+		},
+		{
+			name:     "role is kept",
+			markdown: "see :ref:`mycli-auth`",
+			expected: "see :ref:`mycli-auth`",
+		},
+		{
+			name:     "bold and emphasis",
+			markdown: "**required** and __strong__ with _emphasis_ but not snake_case_name",
+			expected: "**required** and **strong** with *emphasis* but not snake_case_name",
+		},
+		{
+			name:     "adjacent emphasis",
+			markdown: "_a_ _b_, (_c_) and snake_case _d_",
+			expected: "*a* *b*, (*c*) and snake_case *d*",
+		},
+		{
+			name:     "link",
+			markdown: "read [the docs](https://example.com/docs) now",
+			expected: "read `the docs <https://example.com/docs>`__ now",
+		},
+		{
+			name:     "list",
+			markdown: "Supported values:\n* one\n* two\nDone.",
+			expected: "Supported values:\n\n- one\n- two\n\nDone.",
+		},
+		{
+			name:     "heading",
+			markdown: "## Notes",
+			expected: "**Notes**",
+		},
+		{
+			name:     "fenced code",
+			markdown: "Run:\n```sh\nmycli `x` **y**\n```\nafter",
+			expected: "Run:\n\n.. code-block:: sh\n\n   mycli `x` **y**\n\nafter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToRST(tt.markdown); got != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenDocsMarkdown(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:   "md <name>",
+		Short: "Short with `code`",
+		Long:  "Long with [link](https://example.com)",
+		Run:   emptyRun,
+		Annotations: map[string]string{
+			"nameDesc": "the `name`",
+		},
+	}
+	cmd.Flags().String("flag", "", "flag with **bold**")
+
+	buf := new(bytes.Buffer)
+	if err := GenDocs(cmd, buf, WithMarkdown()); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+	checkStringContains(t, output, "Short with ``code``")
+	checkStringContains(t, output, "Long with `link <https://example.com>`__")
+	checkStringContains(t, output, "     - the ``name``\n")
+	checkStringContains(t, output, "     - flag with **bold**\n")
+}
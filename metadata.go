@@ -0,0 +1,148 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Command annotations customizing the page metadata of a single command.
+const (
+	// KeywordsAnnotation holds comma separated keywords added to the ones of WithMetadata.
+	KeywordsAnnotation = "keywords"
+	// MetaDescriptionAnnotation replaces the short description as meta description.
+	MetaDescriptionAnnotation = "metaDescription"
+	// FacetsAnnotation holds the JSON encoded facets of the page, see SetFacets.
+	FacetsAnnotation = "facets"
+)
+
+// Facet is a Snooty facet tagging the page for search, for example the "genre" facet with the "reference" value.
+type Facet struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// WithMetadata adds a meta directive to every page, with the short description of the command
+// as description and as keywords the words of the command path, its aliases, its SuggestFor,
+// the given keywords and the ones of its KeywordsAnnotation.
+func WithMetadata(keywords ...string) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.metadata = true
+		options.keywords = append(options.keywords, keywords...)
+	}
+}
+
+// WithFacets adds facet directives to every page.
+// Facets of the FacetsAnnotation of a command replace the ones with the same name.
+func WithFacets(facets ...Facet) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.facets = append(options.facets, facets...)
+	}
+}
+
+// SetFacets stores facets in the FacetsAnnotation of cmd.
+func SetFacets(cmd *cobra.Command, facets ...Facet) error {
+	b, err := json.Marshal(facets)
+	if err != nil {
+		return err
+	}
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[FacetsAnnotation] = string(b)
+	return nil
+}
+
+// Facets returns the facets of cmd, if any.
+func Facets(cmd *cobra.Command) ([]Facet, error) {
+	annotation, ok := cmd.Annotations[FacetsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var facets []Facet
+	if err := json.Unmarshal([]byte(annotation), &facets); err != nil {
+		return nil, fmt.Errorf("%s: invalid %s annotation: %w", cmd.CommandPath(), FacetsAnnotation, err)
+	}
+	return facets, nil
+}
+
+// pageFacets returns the global facets, replaced or completed by the ones of cmd.
+func (o *GenDocsOptions) pageFacets(cmd *cobra.Command) ([]Facet, error) {
+	own, err := Facets(cmd)
+	if err != nil {
+		return nil, err
+	}
+	facets := make([]Facet, 0, len(o.facets)+len(own))
+	for _, facet := range o.facets {
+		if !slices.ContainsFunc(own, func(f Facet) bool { return f.Name == facet.Name }) {
+			facets = append(facets, facet)
+		}
+	}
+	return append(facets, own...), nil
+}
+
+// pageKeywords returns the unique keywords of the page of cmd.
+func (o *GenDocsOptions) pageKeywords(cmd *cobra.Command) []string {
+	candidates := slices.Concat(
+		strings.Fields(cmd.CommandPath()),
+		aliasPaths(cmd),
+		cmd.SuggestFor,
+		o.keywords,
+		strings.Split(cmd.Annotations[KeywordsAnnotation], ","),
+	)
+
+	var keywords []string
+	for _, keyword := range candidates {
+		keyword = metaValue(keyword)
+		if keyword != "" && !slices.Contains(keywords, keyword) {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
+// metaValue returns text on a single line, meta directive fields not being parsed as RST.
+func metaValue(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func printMetadata(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) error {
+	facets, err := options.pageFacets(cmd)
+	if err != nil {
+		return err
+	}
+	for _, facet := range facets {
+		_, _ = fmt.Fprintf(buf, "\n.. facet::\n   :name: %s\n   :values: %s\n", facet.Name, strings.Join(facet.Values, ", "))
+	}
+	if !options.metadata {
+		return nil
+	}
+	buf.WriteString("\n.. meta::\n")
+	buf.WriteString("   :keywords: " + strings.Join(options.pageKeywords(cmd), ", ") + "\n")
+	description, ok := cmd.Annotations[MetaDescriptionAnnotation]
+	if !ok {
+		description = cmd.Short
+	}
+	if description = metaValue(description); description != "" {
+		buf.WriteString("   :description: " + description + "\n")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestGenDocsMetadata(t *testing.T) {
+	list, _, err := groupedTree().Find([]string{"clusters", "list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := GenDocs(list, buf, WithoutDate()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringOmits(t, buf.String(), ".. meta::")
+	checkStringOmits(t, buf.String(), ".. facet::")
+
+	buf.Reset()
+	if err := GenDocs(list, buf, WithoutDate(), WithMetadata("cli", "clusters")); err != nil {
+		t.Fatal(err)
+	}
+	checkStringContains(t, buf.String(), "   :class: singlecol\n\n"+
+		".. meta::\n"+
+		"   :keywords: mycli, clusters, list, cli\n"+
+		"   :description: List clusters\n\n"+
+		"List clusters\n")
+}
+
+func TestGenDocsMetadataAnnotations(t *testing.T) {
+	root := &cobra.Command{Use: "mycli"}
+	echo := &cobra.Command{
+		Use:        "echo",
+		Aliases:    []string{"say"},
+		SuggestFor: []string{"repeat"},
+		Short:      "Echo anything",
+		Annotations: map[string]string{
+			KeywordsAnnotation:        "print, output",
+			MetaDescriptionAnnotation: "Print text\nto the terminal.",
+		},
+		Run: emptyRun,
+	}
+	root.AddCommand(echo)
+	if err := SetFacets(echo, Facet{Name: "programming_language", Values: []string{"shell", "powershell"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	options := []GenDocsOption{
+		WithoutDate(),
+		WithMetadata(),
+		WithFacets(
+			Facet{Name: "genre", Values: []string{"reference"}},
+			Facet{Name: "programming_language", Values: []string{"shell"}},
+		),
+	}
+	if err := GenDocs(echo, buf, options...); err != nil {
+		t.Fatal(err)
+	}
+	checkStringContains(t, buf.String(), "\n.. facet::\n   :name: genre\n   :values: reference\n"+
+		"\n.. facet::\n   :name: programming_language\n   :values: shell, powershell\n"+
+		"\n.. meta::\n"+
+		"   :keywords: mycli, echo, mycli say, repeat, print, output\n"+
+		"   :description: Print text to the terminal.\n")
+
+	echo.Annotations[FacetsAnnotation] = "{"
+	if err := GenDocs(echo, buf, options...); err == nil {
+		t.Fatal("expected an error for the invalid facets annotation")
+	}
+}
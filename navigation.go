@@ -0,0 +1,104 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const breadcrumbSeparator = " > "
+
+// WithBreadcrumbs prints, below the title of every subcommand page, links to the pages of its parents.
+func WithBreadcrumbs() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.breadcrumbs = true
+	}
+}
+
+// WithSiblingLinks adds a Sibling Commands section listing the other subcommands of the parent.
+func WithSiblingLinks() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.siblingLinks = true
+	}
+}
+
+// printBreadcrumbs prints the path of cmd with links to the pages of its parents,
+// for example ":ref:`mycli <mycli>` > :ref:`a <mycli-a>` > b".
+func printBreadcrumbs(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) {
+	if !options.breadcrumbs || !cmd.HasParent() {
+		return
+	}
+	var crumbs []string
+	for p := cmd.Parent(); p != nil; p = p.Parent() {
+		crumbs = append([]string{fmt.Sprintf(":ref:`%s <%s>`", p.Name(), options.label(p))}, crumbs...)
+	}
+	crumbs = append(crumbs, cmd.Name())
+	buf.WriteString("\n" + strings.Join(crumbs, breadcrumbSeparator) + "\n")
+}
+
+func printRelatedCommands(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) {
+	if !options.hasRelatedCommands(cmd) {
+		return
+	}
+	buf.WriteString(options.pageStyle.section("Related Commands") + "\n")
+	printCommandGroups(buf, commandGroups(cmd, options.isRelatedCommand), options)
+}
+
+func printSiblingCommands(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) {
+	if !options.siblingLinks || !cmd.HasParent() {
+		return
+	}
+	isSibling := func(c *cobra.Command) bool {
+		return c != cmd && options.isRelatedCommand(c)
+	}
+	groups := commandGroups(cmd.Parent(), isSibling)
+	if len(groups) == 0 {
+		return
+	}
+	buf.WriteString(options.pageStyle.section("Sibling Commands") + "\n")
+	printCommandGroups(buf, groups, options)
+}
+
+// printCommandGroups lists the commands with their short description, under the title of their group.
+func printCommandGroups(buf *bytes.Buffer, groups []commandGroup, options *GenDocsOptions) {
+	for _, group := range groups {
+		if group.title != "" {
+			buf.WriteString(options.pageStyle.subsection(group.title) + "\n")
+		}
+		for _, c := range group.commands {
+			_, _ = fmt.Fprintf(buf, "* :ref:`%s` - %s\n", options.label(c), options.formatText(c, c.Short))
+		}
+		buf.WriteString("\n")
+	}
+}
+
+func printToctree(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) {
+	if !options.hasToctree(cmd) {
+		return
+	}
+	buf.WriteString(tocHeader)
+	buf.WriteString("\n")
+	// grouped like the related commands, help topics being ungrouped
+	for _, group := range commandGroups(cmd, options.isDocumented) {
+		for _, c := range group.commands {
+			_, _ = fmt.Fprintf(buf, "   %s </command/%s>\n", c.Name(), commandRef(c))
+		}
+	}
+	buf.WriteString("\n")
+}
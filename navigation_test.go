@@ -0,0 +1,73 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGenDocsBreadcrumbs(t *testing.T) {
+	root := groupedTree()
+	list, _, err := root.Find([]string{"clusters", "list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := GenDocs(list, buf, WithoutDate()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringOmits(t, buf.String(), ":ref:`clusters <mycli-clusters>`")
+
+	buf.Reset()
+	if err := GenDocs(list, buf, WithoutDate(), WithBreadcrumbs()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringContains(t, buf.String(), "   :class: singlecol\n\n"+
+		":ref:`mycli <mycli>` > :ref:`clusters <mycli-clusters>` > list\n\nList clusters\n")
+
+	buf.Reset()
+	if err := GenDocs(root, buf, WithoutDate(), WithBreadcrumbs()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringOmits(t, buf.String(), " > ")
+}
+
+func TestGenDocsSiblingLinks(t *testing.T) {
+	root := groupedTree()
+	login, _, err := root.Find([]string{"login"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := GenDocs(login, buf, WithoutDate(), WithSiblingLinks()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringContains(t, buf.String(), "Sibling Commands\n----------------\n\n"+
+		"Management Commands\n~~~~~~~~~~~~~~~~~~~\n\n"+
+		"* :ref:`mycli-backups` - Manage backups\n"+
+		"* :ref:`mycli-clusters` - Manage clusters\n\n"+
+		"Additional Commands\n~~~~~~~~~~~~~~~~~~~\n\n"+
+		"* :ref:`mycli-version` - Print the version\n\n")
+	checkStringOmits(t, buf.String(), "Authentication Commands")
+
+	buf.Reset()
+	if err := GenDocs(root, buf, WithoutDate(), WithSiblingLinks()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringOmits(t, buf.String(), "Sibling Commands")
+}
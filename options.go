@@ -39,13 +39,12 @@ var (
 	argsRegex             = regexp.MustCompile(`<[^>]+>|\[[^]]+]`)
 )
 
-func printArgs(buf *bytes.Buffer, cmd *cobra.Command) error {
+func printArgs(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) error {
 	u := argsRegex.FindAllString(cmd.Use, -1)
 	if len(u) == 0 {
 		return nil
 	}
-	buf.WriteString("Arguments\n")
-	buf.WriteString("---------\n\n")
+	buf.WriteString(options.pageStyle.section("Arguments") + "\n")
 	buf.WriteString(optionsHeader)
 	for _, a := range u {
 		value := a[1 : len(a)-1]
@@ -53,7 +52,10 @@ func printArgs(buf *bytes.Buffer, cmd *cobra.Command) error {
 		if !hasDescription {
 			return fmt.Errorf("%w: %s - %s", ErrMissingDescription, cmd.CommandPath(), value)
 		}
+		const descriptionIndentation = 7
+		description = indentContinuation(options.formatText(cmd, description), strings.Repeat(" ", descriptionIndentation))
 		required := strings.HasPrefix(a, "<")
+		value = options.sanitizer.escapeLine(cmd.CommandPath()+" argument name", value)
 		line := fmt.Sprintf("   * - %s\n     - string\n     - %v\n     - %s\n", value, required, description)
 		buf.WriteString(line)
 	}
@@ -62,22 +64,93 @@ func printArgs(buf *bytes.Buffer, cmd *cobra.Command) error {
 	return nil
 }
 
-func printOptions(buf *bytes.Buffer, cmd *cobra.Command) {
+const deprecatedOptionsDescription = `The following options are deprecated and will be removed in a future release.
+
+`
+
+func printOptions(buf *bytes.Buffer, cmd *cobra.Command, o flagUsagesOptions, style PageStyle) {
 	flags := cmd.NonInheritedFlags()
-	if flags.HasAvailableFlags() {
-		buf.WriteString("Options\n")
-		buf.WriteString("-------\n\n")
-		buf.WriteString(optionsHeader)
-		buf.WriteString(indentString(FlagUsages(flags), " "))
-		buf.WriteString("\n")
+	if o.hasDocumentedFlags(flags) {
+		buf.WriteString(style.section("Options") + "\n")
+		printFlagDocs(buf, cmd, o, style, documentedFlags(flags, o, false))
 	}
 
 	parentFlags := cmd.InheritedFlags()
-	if parentFlags.HasAvailableFlags() {
-		buf.WriteString("Inherited Options\n")
-		buf.WriteString("-----------------\n\n")
+	if o.hasDocumentedFlags(parentFlags) {
+		buf.WriteString(style.section("Inherited Options") + "\n")
+		printFlagDocs(buf, cmd, o, style, documentedFlags(parentFlags, o, false))
+	}
+
+	if !o.deprecatedSection {
+		return
+	}
+	deprecated := append(documentedFlags(flags, o, true), documentedFlags(parentFlags, o, true)...)
+	if len(deprecated) == 0 {
+		return
+	}
+	buf.WriteString(style.section("Deprecated Options") + "\n")
+	buf.WriteString(deprecatedOptionsDescription)
+	printFlagDocs(buf, cmd, o, style, deprecated)
+}
+
+// printFlagDocs writes the flags either as a list-table or as option directives.
+func printFlagDocs(buf *bytes.Buffer, cmd *cobra.Command, o flagUsagesOptions, style PageStyle, docs []flagDoc) {
+	if !o.directives {
 		buf.WriteString(optionsHeader)
-		buf.WriteString(indentString(FlagUsages(parentFlags), " "))
+		buf.WriteString(indentString(flagRows(docs), " "))
 		buf.WriteString("\n")
+		return
+	}
+
+	buf.WriteString(".. program:: " + style.program(cmd) + "\n\n")
+	for _, d := range docs {
+		buf.WriteString(".. _" + style.FlagLabel(cmd, d.flag.Name) + ":\n\n")
+		buf.WriteString(d.directive())
+		buf.WriteString("\n")
+	}
+}
+
+// FlagLabel returns the label of a flag on the page of cmd, to be used with the :ref: role.
+// Labels are only generated when options are rendered with WithOptionDirectives.
+// Use PageStyle.FlagLabel for pages generated with WithPageStyle.
+func FlagLabel(cmd *cobra.Command, name string) string {
+	return MongoDBPageStyle.FlagLabel(cmd, name)
+}
+
+// FlagRef returns an :option: role referencing a flag documented on the page of cmd.
+// Options are only referenceable when rendered with WithOptionDirectives.
+// Use PageStyle.FlagRef for pages generated with WithPageStyle.
+func FlagRef(cmd *cobra.Command, name string) string {
+	return MongoDBPageStyle.FlagRef(cmd, name)
+}
+
+// WithOptionDirectives renders flags as ".. option::" directives under a ".. program::"
+// directive, with a label per flag, instead of a list-table, so they can be cross-referenced.
+func WithOptionDirectives() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.flagUsages.directives = true
+	}
+}
+
+// WithHiddenFlags documents hidden flags alongside visible ones, useful for internal docs builds.
+func WithHiddenFlags() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.flagUsages.includeHidden = true
+	}
+}
+
+// WithDeprecatedOptionsSection moves deprecated flags out of the options tables
+// into a separate "Deprecated Options" table that includes the deprecation message.
+func WithDeprecatedOptionsSection() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.flagUsages.deprecatedSection = true
+	}
+}
+
+// WithDeprecatedShorthands documents deprecated shorthands in the flag description
+// instead of silently omitting them.
+func WithDeprecatedShorthands() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.flagUsages.deprecatedShorthands = true
 	}
 }
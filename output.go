@@ -16,26 +16,78 @@ package cobra2snooty
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"regexp"
+	"io"
 	"strings"
+	"sync"
 	"text/tabwriter"
+	"text/template"
+	"text/template/parse"
 
 	"github.com/spf13/cobra"
 )
 
 const (
-	outputHeader = `Output
-------
-`
-	outputDescription = `
+	outputSuccessDescription = `
 If the command succeeds, the CLI returns output similar to the following sample. Values in brackets represent your values.
-
-.. code-block::
-
+`
+	outputSampleDescription = `
+If the command succeeds, the CLI returns output similar to the following sample.
+`
+	outputFormatsDescription = `
+If the command succeeds, the CLI returns output similar to the following samples, depending on the output format.
 `
 )
 
+// OutputFormatsAnnotation is the command annotation holding the JSON encoded additional output formats.
+const OutputFormatsAnnotation = "outputFormats"
+
+// OutputFormat documents one of the output formats of a command, for example the JSON output of "-o json".
+type OutputFormat struct {
+	// Name is the title of the tab or heading, e.g. "JSON".
+	Name string `json:"name"`
+	// Language is the language of the code-block. Content in json is pretty-printed.
+	Language string `json:"language,omitempty"`
+	// Content is the sample output or schema.
+	Content string `json:"content"`
+}
+
+// SetOutputFormats stores additional output formats in the OutputFormatsAnnotation of cmd.
+// The text output from the "output" annotation, if any, is always documented first.
+func SetOutputFormats(cmd *cobra.Command, formats ...OutputFormat) error {
+	b, err := json.Marshal(formats)
+	if err != nil {
+		return err
+	}
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[OutputFormatsAnnotation] = string(b)
+	return nil
+}
+
+// OutputFormats returns the additional output formats of cmd, if any.
+func OutputFormats(cmd *cobra.Command) ([]OutputFormat, error) {
+	annotation, ok := cmd.Annotations[OutputFormatsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var formats []OutputFormat
+	if err := json.Unmarshal([]byte(annotation), &formats); err != nil {
+		return nil, fmt.Errorf("%s: invalid %s annotation: %w", cmd.CommandPath(), OutputFormatsAnnotation, err)
+	}
+	return formats, nil
+}
+
+// WithOutputFormatHeadings renders multiple output formats under sub-headings instead of tabs.
+func WithOutputFormatHeadings() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.outputFormatHeadings = true
+	}
+}
+
 const (
 	tabwriterMinWidth = 6
 	tabwriterWidth    = 4
@@ -43,46 +95,407 @@ const (
 	tabwriterPadChar  = ' '
 )
 
-// regex for one or more characters except right curly bracket '}'.
-const charsExceptRightCurlyBracket = "[^}]+"
+// OutputSampleAnnotation is the command annotation holding the JSON sample data
+// the output template is executed with to render the Output section.
+const OutputSampleAnnotation = "outputSample"
+
+// placeholder for the "%s" verb some output templates are formatted with before being executed.
+const namePlaceholder = "<Name>"
+
+var ErrUnsupportedTemplate = errors.New("unsupported output template")
+
+// Command annotations overriding the Output section text of a single command.
+const (
+	OutputDescriptionAnnotation = "outputDescription"
+	OutputCaptionAnnotation     = "outputCaption"
+	OutputLanguageAnnotation    = "outputLanguage"
+	// NoOutputAnnotation marks commands that print nothing, its value optionally replaces the default wording.
+	NoOutputAnnotation = "noOutput"
+)
+
+const noOutputDescription = "If the command succeeds, it doesn't return any output."
+
+// OutputStyle configures the text of the Output section. Empty fields keep the default text.
+type OutputStyle struct {
+	// Description is the paragraph before the sample output.
+	Description string
+	// Caption is the caption of the sample output code-block.
+	Caption string
+	// Language is the language of the sample output code-block.
+	Language string
+	// NoOutputDescription is the paragraph used for commands marked with NoOutputAnnotation.
+	NoOutputDescription string
+}
+
+// forCommand applies the annotation overrides of cmd to the style.
+func (s OutputStyle) forCommand(cmd *cobra.Command) OutputStyle {
+	if v, ok := cmd.Annotations[OutputDescriptionAnnotation]; ok {
+		s.Description = v
+	}
+	if v, ok := cmd.Annotations[OutputCaptionAnnotation]; ok {
+		s.Caption = v
+	}
+	if v, ok := cmd.Annotations[OutputLanguageAnnotation]; ok {
+		s.Language = v
+	}
+	if v := cmd.Annotations[NoOutputAnnotation]; v != "" {
+		s.NoOutputDescription = v
+	}
+	if s.NoOutputDescription == "" {
+		s.NoOutputDescription = noOutputDescription
+	}
+	return s
+}
+
+// WithOutputStyle configures the text of the Output section for all commands.
+// Commands can override it with the OutputDescriptionAnnotation, OutputCaptionAnnotation
+// and OutputLanguageAnnotation annotations.
+func WithOutputStyle(style OutputStyle) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.outputStyle = style
+	}
+}
+
+// OutputFormatter writes the Output section of a command.
+type OutputFormatter func(w io.Writer, cmd *cobra.Command) error
+
+// DefaultOutputFormatter returns the OutputFormatter GenDocs uses when none is set, configured with
+// the output and page style options among genDocOptions. Custom formatters wrapping it should
+// pass the options given to GenDocs to render the same Output section.
+func DefaultOutputFormatter(genDocOptions ...GenDocsOption) OutputFormatter {
+	options := newGenDocsOptions(genDocOptions)
+	return func(w io.Writer, cmd *cobra.Command) error {
+		buf := new(bytes.Buffer)
+		if err := printOutputCreate(buf, cmd, options); err != nil {
+			return err
+		}
+		_, err := buf.WriteTo(w)
+		return err
+	}
+}
+
+// WithCustomOutputFormatter replaces how the Output section is written.
+func WithCustomOutputFormatter(customFormatter OutputFormatter) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.outputFormatter = customFormatter
+	}
+}
 
 // This function can return the output for all commands when the output template is added as an annotation in the command file
 
-func printOutputCreate(buf *bytes.Buffer, cmd *cobra.Command) {
-	if cmd.Annotations["output"] == "" {
-		return
+func printOutputCreate(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) error {
+	style := options.outputStyle.forCommand(cmd)
+	if _, ok := cmd.Annotations[NoOutputAnnotation]; ok {
+		buf.WriteString(options.pageStyle.section("Output"))
+		buf.WriteString("\n" + style.NoOutputDescription + "\n\n")
+		return nil
 	}
 
-	output := removeRange(cmd.Annotations["output"])
-	output = replaceWithValueOrDefault(output)
-	output = strings.ReplaceAll(output, "{{end}}", "")
-	output = strings.ReplaceAll(output, "{{.", "<")
-	output = strings.ReplaceAll(output, "}}", ">")
-	output = strings.ReplaceAll(output, "%s", "<Name>")
-	output = strings.Replace(output, "   ", "", 1)
-	output = strings.ReplaceAll(output, "\n", "\n   ")
-	w := new(tabwriter.Writer)
-	w.Init(buf, tabwriterMinWidth, tabwriterWidth, tabwriterPadding, tabwriterPadChar, 0)
+	formats, err := OutputFormats(cmd)
+	if err != nil {
+		return err
+	}
+	if cmd.Annotations["output"] == "" && len(formats) == 0 {
+		return nil
+	}
+
+	var output string
+	description := outputSuccessDescription
+	if cmd.Annotations["output"] != "" {
+		if _, ok := cmd.Annotations[OutputSampleAnnotation]; ok {
+			output, err = outputSample(cmd, options.outputFuncs)
+			description = outputSampleDescription
+		} else {
+			output, err = outputPlaceholders(cmd.Annotations["output"])
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", cmd.CommandPath(), err)
+		}
+	}
+
+	buf.WriteString(options.pageStyle.section("Output"))
+	if len(formats) == 0 {
+		if style.Description == "" {
+			buf.WriteString(description)
+		} else {
+			buf.WriteString("\n" + style.Description + "\n")
+		}
+		buf.WriteString("\n")
+		buf.WriteString(outputCodeBlock(style.Language, style.Caption, ""))
+		buf.WriteString(alignOutput(output, "   "))
+		buf.WriteString("\n")
+		return nil
+	}
+	return printOutputFormats(buf, cmd, output, formats, style, options)
+}
 
-	buf.WriteString(outputHeader)
-	buf.WriteString(outputDescription)
-	fmt.Fprintln(w, "   "+output)
+// outputCodeBlock returns the code-block directive for a sample output.
+func outputCodeBlock(language, caption, indent string) string {
+	directive := strings.TrimSpace(".. code-block:: "+language) + "\n"
+	if caption != "" {
+		directive += indent + "   :caption: " + caption + "\n"
+	}
+	return directive + "\n"
+}
+
+// alignOutput aligns the tab separated columns of output and indents it.
+func alignOutput(output, indent string) string {
+	b := new(bytes.Buffer)
+	w := new(tabwriter.Writer)
+	w.Init(b, tabwriterMinWidth, tabwriterWidth, tabwriterPadding, tabwriterPadChar, 0)
+	fmt.Fprintln(w, indent+strings.ReplaceAll(output, "\n", "\n"+indent))
 	w.Flush()
+	return b.String()
+}
+
+func printOutputFormats(buf *bytes.Buffer, cmd *cobra.Command, text string, formats []OutputFormat, style OutputStyle, options *GenDocsOptions) error {
+	headings := options.outputFormatHeadings
+	if text != "" {
+		formats = append([]OutputFormat{{Name: "Text", Language: style.Language, Content: text}}, formats...)
+	}
+	if style.Description == "" {
+		buf.WriteString(outputFormatsDescription)
+	} else {
+		buf.WriteString("\n" + style.Description + "\n")
+	}
 	buf.WriteString("\n")
+	if !headings {
+		buf.WriteString(".. tabs::\n\n")
+	}
+	for i, format := range formats {
+		content := format.Content
+		if format.Language == "json" && (i > 0 || text == "") {
+			pretty := new(bytes.Buffer)
+			if err := json.Indent(pretty, []byte(content), "", "  "); err != nil {
+				return fmt.Errorf("%s: invalid JSON for output format %q: %w", cmd.CommandPath(), format.Name, err)
+			}
+			content = pretty.String()
+		}
+		indent := "   "
+		if headings {
+			buf.WriteString(options.pageStyle.subsection(format.Name) + "\n")
+		} else {
+			_, _ = fmt.Fprintf(buf, "   .. tab:: %s\n      :tabid: %s\n\n", format.Name, tabID(format.Name))
+			indent = "         "
+			buf.WriteString("      ")
+		}
+		if i == 0 && text != "" {
+			buf.WriteString(outputCodeBlock(format.Language, style.Caption, indent[:len(indent)-3]))
+			buf.WriteString(alignOutput(content, indent))
+		} else {
+			buf.WriteString(outputCodeBlock(format.Language, "", ""))
+			buf.WriteString(indentString(strings.TrimRight(content, "\n"), indent) + "\n")
+		}
+		buf.WriteString("\n")
+	}
+	return nil
+}
+
+// tabID returns a tab id for a title, e.g. "JSON Schema" becomes "json-schema".
+func tabID(title string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(title)), " ", separator)
+}
+
+// outputSamples keeps the values given to SetOutputSample by command, so the output templates
+// are executed with them rather than with their JSON encoding, which has other field names and types.
+var outputSamples sync.Map
+
+type outputSampleValue struct {
+	annotation string
+	data       any
 }
 
-func removeRange(text string) string {
-	// remove {{range}} control structure. Examples: {{range .}}, {{range .Results}}
-	re := `{{range ` + charsExceptRightCurlyBracket + `}}`
-	return regexp.MustCompile(re).ReplaceAllString(text, "")
+// SetOutputSample sets the sample data the output template of cmd is executed with,
+// usually a value of the type the command prints. Its JSON encoding is stored in the
+// OutputSampleAnnotation of cmd.
+func SetOutputSample(cmd *cobra.Command, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[OutputSampleAnnotation] = string(b)
+	outputSamples.Store(cmd, outputSampleValue{annotation: string(b), data: data})
+	return nil
 }
 
-func replaceWithValueOrDefault(text string) string {
-	// replaces {{if .field}}{{.field}}{{else}}defaultValue{{end}} with {{.field}}
-	re := `{{if` + charsExceptRightCurlyBracket + `}}` +
-		`({{` + charsExceptRightCurlyBracket + `}})` +
-		`{{else}}` + charsExceptRightCurlyBracket + `{{end}}`
+// outputSampleData returns the value given to SetOutputSample or, when the annotation was set
+// otherwise, its JSON decoding with numbers as json.Number.
+func outputSampleData(cmd *cobra.Command) (any, error) {
+	annotation := cmd.Annotations[OutputSampleAnnotation]
+	if v, ok := outputSamples.Load(cmd); ok && v.(outputSampleValue).annotation == annotation {
+		return v.(outputSampleValue).data, nil
+	}
+	var data any
+	d := json.NewDecoder(strings.NewReader(annotation))
+	d.UseNumber()
+	if err := d.Decode(&data); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", OutputSampleAnnotation, err)
+	}
+	return data, nil
+}
 
-	// $1 is the first group (surrounded by round brackets in the regex expression)
-	return regexp.MustCompile(re).ReplaceAllString(text, "$1")
+// WithOutputTemplateFuncs adds the functions used by the output templates, needed to execute them with sample data.
+func WithOutputTemplateFuncs(funcs template.FuncMap) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		if options.outputFuncs == nil {
+			options.outputFuncs = template.FuncMap{}
+		}
+		for name, fn := range funcs {
+			options.outputFuncs[name] = fn
+		}
+	}
+}
+
+// outputSample executes the output template of cmd with its sample data,
+// failing on keys missing from the sample instead of printing "<no value>".
+func outputSample(cmd *cobra.Command, funcs template.FuncMap) (string, error) {
+	data, err := outputSampleData(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	text := strings.ReplaceAll(cmd.Annotations["output"], "%s", namePlaceholder)
+	t, err := template.New("output").Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnsupportedTemplate, err)
+	}
+	b := new(strings.Builder)
+	if err := t.Execute(b, data); err != nil {
+		return "", err
+	}
+	return trimTrailingSpaces(b.String()), nil
+}
+
+// outputPlaceholders renders an output template as a sample where every value is replaced
+// by a placeholder named after the field it comes from, e.g. {{.Name}} becomes <Name>.
+func outputPlaceholders(text string) (string, error) {
+	trees := map[string]*parse.Tree{}
+	t := parse.New("output")
+	t.Mode = parse.SkipFuncCheck
+	if _, err := t.Parse(text, "", "", trees); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnsupportedTemplate, err)
+	}
+
+	r := &placeholderRenderer{trees: trees, b: new(strings.Builder)}
+	if err := r.walk(trees["output"].Root); err != nil {
+		return "", err
+	}
+
+	return trimTrailingSpaces(r.b.String()), nil
+}
+
+func trimTrailingSpaces(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+type placeholderRenderer struct {
+	trees map[string]*parse.Tree
+	b     *strings.Builder
+	depth int
+}
+
+func (r *placeholderRenderer) walk(node parse.Node) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := r.walk(child); err != nil {
+				return err
+			}
+		}
+	case *parse.TextNode:
+		r.b.WriteString(strings.ReplaceAll(string(n.Text), "%s", namePlaceholder))
+	case *parse.ActionNode:
+		// declarations such as {{$x := .Field}} print nothing
+		if len(n.Pipe.Decl) == 0 {
+			r.b.WriteString(pipePlaceholder(n.Pipe))
+		}
+	case *parse.IfNode:
+		return r.walkBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		// a single sample row
+		return r.walk(n.List)
+	case *parse.WithNode:
+		return r.walkBranch(&n.BranchNode)
+	case *parse.TemplateNode:
+		tree, ok := r.trees[n.Name]
+		if !ok {
+			return fmt.Errorf("%w: template %q is not defined", ErrUnsupportedTemplate, n.Name)
+		}
+		const maxDepth = 10
+		if r.depth >= maxDepth {
+			return fmt.Errorf("%w: template %q is recursive", ErrUnsupportedTemplate, n.Name)
+		}
+		r.depth++
+		defer func() { r.depth-- }()
+		return r.walk(tree.Root)
+	case *parse.CommentNode, *parse.BreakNode, *parse.ContinueNode:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedTemplate, node)
+	}
+	return nil
+}
+
+// walkBranch renders the body of a branch, or its else branch when the body prints nothing.
+func (r *placeholderRenderer) walkBranch(n *parse.BranchNode) error {
+	if n.List != nil && len(n.List.Nodes) > 0 {
+		return r.walk(n.List)
+	}
+	return r.walk(n.ElseList)
+}
+
+// pipePlaceholder returns the placeholder for the value printed by a pipeline,
+// named after the first field used by it, e.g. {{.Created | date}} becomes <Created>.
+func pipePlaceholder(pipe *parse.PipeNode) string {
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			if name := argName(arg); name != "" {
+				return "<" + name + ">"
+			}
+		}
+	}
+	// pipelines of literals, e.g. {{"-"}}
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			if s, ok := arg.(*parse.StringNode); ok {
+				return s.Text
+			}
+		}
+	}
+	return "<Value>"
+}
+
+func argName(arg parse.Node) string {
+	switch a := arg.(type) {
+	case *parse.FieldNode:
+		return strings.Join(a.Ident, ".")
+	case *parse.ChainNode:
+		if len(a.Field) > 0 {
+			return strings.Join(a.Field, ".")
+		}
+		return argName(a.Node)
+	case *parse.VariableNode:
+		if len(a.Ident) > 1 {
+			return strings.Join(a.Ident[1:], ".")
+		}
+		if a.Ident[0] != "$" {
+			return strings.TrimPrefix(a.Ident[0], "$")
+		}
+	case *parse.PipeNode:
+		if name := pipePlaceholder(a); name != "<Value>" {
+			return strings.Trim(name, "<>")
+		}
+	}
+	return ""
 }
@@ -16,18 +16,27 @@ package cobra2snooty
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
 	"testing"
+	"text/template"
 
 	"github.com/spf13/cobra"
 )
 
+const outputHeader = `Output
+------
+`
+
 func TestPrintOutputCreate(t *testing.T) {
 	t.Run("replaceWithValueOrDefault", func(t *testing.T) {
 		outputTemplate := `ID	NAME	DATABASE	COLLECTION	TYPE   
 {{range .}}{{.IndexID}}	%s	{{.Database}}	{{.CollectionName}}	{{if .Type }}{{.Type}}{{else}}defaultValue{{end}}
 {{end}}`
 
-		expected := outputHeader + outputDescription +
+		expected := outputHeader + outputSuccessDescription + "\n.. code-block::\n\n" +
 			`   ID          NAME     DATABASE     COLLECTION         TYPE
    <IndexID>   <Name>   <Database>   <CollectionName>   <Type>
    
@@ -41,11 +50,208 @@ func TestPrintOutputCreate(t *testing.T) {
 		}
 
 		buf := new(bytes.Buffer)
-		printOutputCreate(buf, cmd)
+		if err := printOutputCreate(buf, cmd, newGenDocsOptions(nil)); err != nil {
+			t.Fatal(err)
+		}
 		result := buf.String()
 
 		if result != expected {
 			t.Errorf("expected:\n[%s]\ngot:\n[%s]\n", expected, result)
 		}
 	})
+	t.Run("sample data", func(t *testing.T) {
+		cmd := &cobra.Command{
+			Annotations: map[string]string{
+				"output": "ID\tNAME\tSIZE\n{{range .}}{{.ID}}\t{{.Name}}\t{{bytes .Size}}\n{{end}}",
+			},
+		}
+		type index struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			Size int    `json:"size"`
+		}
+		err := SetOutputSample(cmd, []index{
+			{ID: "5e4e593f70dfbf1010295836", Name: "orders", Size: 1048576},
+			{ID: "5e4e593f70dfbf1010295837", Name: "users", Size: 42},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := outputHeader + outputSampleDescription + "\n.. code-block::\n\n" +
+			`   ID                         NAME     SIZE
+   5e4e593f70dfbf1010295836   orders   1048576B
+   5e4e593f70dfbf1010295837   users    42B
+   
+
+`
+		buf := new(bytes.Buffer)
+		options := newGenDocsOptions([]GenDocsOption{
+			WithOutputTemplateFuncs(template.FuncMap{
+				"bytes": func(n int) string { return strconv.Itoa(n) + "B" },
+			}),
+		})
+		if err := printOutputCreate(buf, cmd, options); err != nil {
+			t.Fatal(err)
+		}
+		if result := buf.String(); result != expected {
+			t.Errorf("expected:\n[%s]\ngot:\n[%s]\n", expected, result)
+		}
+	})
+
+	t.Run("sample annotation", func(t *testing.T) {
+		cmd := &cobra.Command{
+			Annotations: map[string]string{
+				"output":               "{{range .}}{{.ID}} {{bytes .Size}}\n{{end}}",
+				OutputSampleAnnotation: `[{"ID": "5e4e593f70dfbf1010295836", "Size": 42}]`,
+			},
+		}
+		options := newGenDocsOptions([]GenDocsOption{
+			WithOutputTemplateFuncs(template.FuncMap{
+				"bytes": func(n json.Number) string { return n.String() + "B" },
+			}),
+		})
+		buf := new(bytes.Buffer)
+		if err := printOutputCreate(buf, cmd, options); err != nil {
+			t.Fatal(err)
+		}
+		checkStringContains(t, buf.String(), "   5e4e593f70dfbf1010295836 42B\n")
+	})
+
+	t.Run("sample missing a key", func(t *testing.T) {
+		cmd := &cobra.Command{
+			Annotations: map[string]string{
+				"output":               "{{range .}}{{.ID}}\n{{end}}",
+				OutputSampleAnnotation: `[{"id": "5e4e593f70dfbf1010295836"}]`,
+			},
+		}
+		if err := printOutputCreate(new(bytes.Buffer), cmd, newGenDocsOptions(nil)); err == nil {
+			t.Fatal("expected an error for the key missing from the sample")
+		}
+	})
+
+	t.Run("unsupported template", func(t *testing.T) {
+		cmd := &cobra.Command{
+			Annotations: map[string]string{
+				"output": `{{template "missing" .}}`,
+			},
+		}
+		if err := printOutputCreate(new(bytes.Buffer), cmd, newGenDocsOptions(nil)); !errors.Is(err, ErrUnsupportedTemplate) {
+			t.Fatalf("expected ErrUnsupportedTemplate, got %v", err)
+		}
+	})
+}
+
+func TestOutputPlaceholders(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "nested range",
+			template: "{{range .Clusters}}{{.Name}}{{range .Nodes}} {{.Host.Name}}{{end}}\n{{end}}",
+			expected: "<Name> <Host.Name>\n",
+		},
+		{
+			name:     "with",
+			template: "{{with .Owner}}{{.Email}}{{else}}none{{end}}",
+			expected: "<Email>",
+		},
+		{
+			name:     "functions and pipelines",
+			template: "{{formatDate .Created}} {{.Size | humanize}} {{len .Items}} {{\"-\"}}",
+			expected: "<Created> <Size> <Items> -",
+		},
+		{
+			name:     "variables and templates",
+			template: `{{define "row"}}{{.ID}}{{end}}{{$p := .Project}}{{$p.Name}} {{template "row" .}}{{/* comment */}}`,
+			expected: "<Name> <ID>",
+		},
+		{
+			name:     "if without body",
+			template: "{{if .Paused}}{{else}}{{.State}}{{end}}",
+			expected: "<State>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := outputPlaceholders(tt.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected:\n[%s]\ngot:\n[%s]", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOutputStyle(t *testing.T) {
+	cmd := &cobra.Command{
+		Annotations: map[string]string{
+			"output":                    "{{.Path}}",
+			OutputDescriptionAnnotation: "The command writes the file path.",
+			OutputLanguageAnnotation:    "none",
+		},
+	}
+	options := newGenDocsOptions([]GenDocsOption{WithOutputStyle(OutputStyle{Caption: "Sample"})})
+
+	buf := new(bytes.Buffer)
+	if err := printOutputCreate(buf, cmd, options); err != nil {
+		t.Fatal(err)
+	}
+	expected := outputHeader + `
+The command writes the file path.
+
+.. code-block:: none
+   :caption: Sample
+
+   <Path>
+
+`
+	if result := buf.String(); result != expected {
+		t.Errorf("expected:\n[%s]\ngot:\n[%s]\n", expected, result)
+	}
+
+	t.Run("no output", func(t *testing.T) {
+		cmd := &cobra.Command{Annotations: map[string]string{NoOutputAnnotation: ""}}
+		buf := new(bytes.Buffer)
+		if err := printOutputCreate(buf, cmd, newGenDocsOptions(nil)); err != nil {
+			t.Fatal(err)
+		}
+		if expected := outputHeader + "\n" + noOutputDescription + "\n\n"; buf.String() != expected {
+			t.Errorf("expected:\n[%s]\ngot:\n[%s]\n", expected, buf.String())
+		}
+	})
+}
+
+func TestDefaultOutputFormatter(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:   "mycli",
+		Short: "Short",
+		Run:   emptyRun,
+		Annotations: map[string]string{
+			"output": "{{.Path}}",
+		},
+	}
+	opts := []GenDocsOption{WithoutDate(), WithOutputStyle(OutputStyle{Caption: "Sample", Language: "none"})}
+
+	expected := new(bytes.Buffer)
+	if err := GenDocs(cmd, expected, opts...); err != nil {
+		t.Fatal(err)
+	}
+	wrapped := DefaultOutputFormatter(opts...)
+	got := new(bytes.Buffer)
+	formatter := WithCustomOutputFormatter(func(w io.Writer, cmd *cobra.Command) error {
+		return wrapped(w, cmd)
+	})
+	if err := GenDocs(cmd, got, append(opts, formatter)...); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != expected.String() {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+	checkStringContains(t, got.String(), ".. code-block:: none\n   :caption: Sample\n")
 }
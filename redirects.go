@@ -0,0 +1,220 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// redirectLine is a redirect rule of the docs platform, where ${prefix} and ${base}
+	// are defined in the header of the redirects file.
+	redirectLine = "raw: ${prefix}/command/%s/ -> ${base}/command/%s/\n"
+	// DefaultRedirectsBase is the base the redirects point to when WithRedirectDefines is given none.
+	DefaultRedirectsBase = "https://www.mongodb.com/${prefix}"
+)
+
+var (
+	ErrMissingRedirectDefine = errors.New("redirects file doesn't define")
+	redirectDefineRegex      = regexp.MustCompile(`(?m)^define:\s+(\w+)\s`)
+)
+
+// Redirect points the page of a command that no longer exists to the page replacing it.
+type Redirect struct {
+	// From is the path of the command that no longer exists.
+	From string
+	// To is the new path of the command or, when it was removed, the path of its closest remaining ancestor.
+	To string
+}
+
+// Redirects returns the redirects for the commands of previous missing from current.
+// renames maps previous command paths to their new path, renames of subcommands follow their parent.
+// Commands renamed to a name they kept as alias are detected as for Diff.
+func Redirects(previous, current *Spec, renames map[string]string) []Redirect {
+	renames = commandRenames(previous, current, renames)
+	var redirects []Redirect
+	for _, prev := range previous.Commands {
+		if current.Command(prev.Path) != nil {
+			continue
+		}
+		target := renamedPath(prev.Path, renames)
+		for target != "" && current.Command(target) == nil {
+			target, _ = splitCommandPath(target)
+		}
+		if target != "" {
+			redirects = append(redirects, Redirect{From: prev.Path, To: target})
+		}
+	}
+	return redirects
+}
+
+// WriteRedirects writes redirects as rules of the docs platform redirects file,
+// between the pages generated by GenTreeDocs in the command directory.
+func WriteRedirects(w io.Writer, redirects []Redirect) error {
+	for _, r := range redirects {
+		from := strings.ReplaceAll(r.From, " ", separator)
+		to := strings.ReplaceAll(r.To, " ", separator)
+		if _, err := fmt.Fprintf(w, redirectLine, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type redirectsOptions struct {
+	filename string
+	previous *Spec
+	renames  map[string]string
+	prefix   string
+	base     string
+}
+
+// WithRedirects makes GenTreeDocs add to filename the redirects from the pages of the commands
+// of previous that are no longer generated, see Redirects. With WithAliasPages, the alias paths
+// of the documented commands are redirected to the page of their command.
+// The rules and defines already in filename are kept, rules are only added once.
+// GenTreeDocs returns ErrMissingRedirectDefine when the file doesn't define the prefix and base
+// of the rules and WithRedirectDefines is not used.
+func WithRedirects(filename string, previous *Spec, renames map[string]string) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		if options.redirects == nil {
+			options.redirects = &redirectsOptions{}
+		}
+		options.redirects.filename = filename
+		options.redirects.previous = previous
+		options.redirects.renames = renames
+	}
+}
+
+// WithRedirectDefines sets the prefix and base defined at the top of the redirects file of WithRedirects
+// when it doesn't define them yet. An empty base defaults to DefaultRedirectsBase.
+func WithRedirectDefines(prefix, base string) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		if options.redirects == nil {
+			options.redirects = &redirectsOptions{}
+		}
+		options.redirects.prefix = prefix
+		options.redirects.base = base
+	}
+}
+
+func genRedirectsFile(cmd *cobra.Command, options *GenDocsOptions) error {
+	o := options.redirects
+	if o.filename == "" {
+		return nil
+	}
+	existing, err := os.ReadFile(o.filename)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	// redirect the pages GenTreeDocs no longer writes, deprecated commands included unless documented
+	current := newSpec(cmd, options.isDocumented)
+	redirects := Redirects(o.previous, current, o.renames)
+	if options.redirectsAliases() {
+		redirects = appendAliasRedirects(redirects, cmd, current, options)
+	}
+	rules := new(bytes.Buffer)
+	if err := WriteRedirects(rules, redirects); err != nil {
+		return err
+	}
+	content, err := mergeRedirects(existing, rules.String(), o)
+	if err != nil {
+		return fmt.Errorf("%s: %w", o.filename, err)
+	}
+	return genFile(o.filename, func(w io.Writer) error {
+		_, err := w.Write(content)
+		return err
+	})
+}
+
+// redirectsAliases reports whether the alias paths are redirected rather than given a stub page.
+func (o *GenDocsOptions) redirectsAliases() bool {
+	return o.aliasPages && o.redirects != nil && o.redirects.filename != ""
+}
+
+// appendAliasRedirects adds to redirects the ones from the alias paths of the documented commands
+// of the tree of cmd to their page, skipping the paths already redirected or documented.
+func appendAliasRedirects(redirects []Redirect, cmd *cobra.Command, current *Spec, options *GenDocsOptions) []Redirect {
+	for _, c := range cmd.Commands() {
+		if !options.isDocumented(c) {
+			continue
+		}
+		for _, path := range aliasPaths(c) {
+			redirected := slices.ContainsFunc(redirects, func(r Redirect) bool { return r.From == path })
+			if !redirected && current.Command(path) == nil {
+				redirects = append(redirects, Redirect{From: path, To: c.CommandPath()})
+			}
+		}
+		redirects = appendAliasRedirects(redirects, c, current, options)
+	}
+	return redirects
+}
+
+// mergeRedirects adds the missing defines at the top of existing and the rules it doesn't have yet at the bottom.
+func mergeRedirects(existing []byte, rules string, o *redirectsOptions) ([]byte, error) {
+	defined := map[string]bool{}
+	for _, m := range redirectDefineRegex.FindAllSubmatch(existing, -1) {
+		defined[string(m[1])] = true
+	}
+	base := o.base
+	if base == "" && o.prefix != "" {
+		base = DefaultRedirectsBase
+	}
+	buf := new(bytes.Buffer)
+	for _, define := range [][2]string{{"prefix", o.prefix}, {"base", base}} {
+		name, value := define[0], define[1]
+		if defined[name] {
+			continue
+		}
+		if value == "" {
+			return nil, fmt.Errorf("%w %s, see WithRedirectDefines", ErrMissingRedirectDefine, name)
+		}
+		buf.WriteString("define: " + name + " " + value + "\n")
+	}
+	if buf.Len() > 0 && len(existing) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.Write(existing)
+
+	lines := map[string]bool{}
+	for _, line := range strings.Split(string(existing), "\n") {
+		lines[strings.TrimSpace(line)] = true
+	}
+	separated := buf.Len() == 0
+	for _, rule := range strings.SplitAfter(rules, "\n") {
+		if rule == "" || lines[strings.TrimSpace(rule)] {
+			continue
+		}
+		if !separated {
+			if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+				buf.WriteString("\n")
+			}
+			buf.WriteString("\n")
+			separated = true
+		}
+		buf.WriteString(rule)
+	}
+	return buf.Bytes(), nil
+}
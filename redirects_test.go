@@ -0,0 +1,185 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRedirects(t *testing.T) {
+	previous, current := NewSpec(previousTree()), NewSpec(currentTree())
+
+	t.Run("aliases and removals", func(t *testing.T) {
+		expected := []Redirect{
+			{From: "mycli old", To: "mycli"},
+			{From: "mycli say", To: "mycli echo"},
+		}
+		if got := Redirects(previous, current, nil); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("renames", func(t *testing.T) {
+		expected := []Redirect{
+			{From: "mycli old", To: "mycli new"},
+			{From: "mycli say", To: "mycli echo"},
+		}
+		if got := Redirects(previous, current, map[string]string{"mycli old": "mycli new"}); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("unsorted spec", func(t *testing.T) {
+		// the child is listed before its parent, both renamed to a name they kept as alias
+		previous := &Spec{Commands: []CommandSpec{{Path: "mycli config set"}, {Path: "mycli config"}, {Path: "mycli"}}}
+		current := &Spec{Commands: []CommandSpec{
+			{Path: "mycli"},
+			{Path: "mycli settings", Aliases: []string{"config"}},
+			{Path: "mycli settings put", Aliases: []string{"set"}},
+		}}
+		expected := []Redirect{
+			{From: "mycli config set", To: "mycli settings put"},
+			{From: "mycli config", To: "mycli settings"},
+		}
+		if got := Redirects(previous, current, nil); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("subcommands follow their parent", func(t *testing.T) {
+		settings := &Spec{Commands: []CommandSpec{{Path: "mycli"}, {Path: "mycli settings"}, {Path: "mycli settings set"}}}
+		expected := []Redirect{
+			{From: "mycli config", To: "mycli settings"},
+			{From: "mycli config set", To: "mycli settings set"},
+			{From: "mycli echo", To: "mycli"},
+			{From: "mycli new", To: "mycli"},
+		}
+		if got := Redirects(current, settings, map[string]string{"mycli config": "mycli settings"}); !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+}
+
+func TestWriteRedirects(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := WriteRedirects(buf, []Redirect{{From: "mycli config set", To: "mycli settings set"}}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "raw: ${prefix}/command/mycli-config-set/ -> ${base}/command/mycli-settings-set/\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf)
+	}
+}
+
+func TestGenTreeDocsRedirects(t *testing.T) {
+	previous := NewSpec(previousTree())
+	root := &cobra.Command{Use: "mycli"}
+	root.AddCommand(
+		&cobra.Command{Use: "echo", Aliases: []string{"say"}, Run: emptyRun},
+		&cobra.Command{Use: "old", Deprecated: "use mycli echo instead", Run: emptyRun},
+	)
+	rules := "raw: ${prefix}/command/mycli-config/ -> ${base}/command/mycli/\n" +
+		"raw: ${prefix}/command/mycli-config-set/ -> ${base}/command/mycli/\n" +
+		"raw: ${prefix}/command/mycli-old/ -> ${base}/command/mycli/\n" +
+		"raw: ${prefix}/command/mycli-say/ -> ${base}/command/mycli-echo/\n"
+	generate := func(t *testing.T, filename string, opts ...GenDocsOption) string {
+		t.Helper()
+		opts = append(opts, WithRedirects(filename, previous, nil))
+		if err := GenTreeDocs(root, t.TempDir(), opts...); err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	t.Run("new file", func(t *testing.T) {
+		got := generate(t, filepath.Join(t.TempDir(), "redirects"), WithRedirectDefines("docs/mycli", ""))
+		expected := "define: prefix docs/mycli\n" +
+			"define: base https://www.mongodb.com/${prefix}\n\n" +
+			rules
+		if got != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+		}
+	})
+
+	t.Run("alias pages", func(t *testing.T) {
+		root := &cobra.Command{Use: "mycli"}
+		root.AddCommand(&cobra.Command{Use: "echo", Aliases: []string{"say", "print"}, Run: emptyRun})
+		dir := t.TempDir()
+		filename := filepath.Join(t.TempDir(), "redirects")
+		err := GenTreeDocs(root, dir, WithAliasPages(), WithRedirects(filename, previous, nil), WithRedirectDefines("docs/mycli", ""))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := string(b)
+		checkStringContains(t, got, "raw: ${prefix}/command/mycli-print/ -> ${base}/command/mycli-echo/\n")
+		if n := strings.Count(got, "/command/mycli-say/"); n != 1 {
+			t.Errorf("expected a single redirect of mycli say, got %d:\n%s", n, got)
+		}
+		for _, alias := range []string{"say", "print"} {
+			if _, err := os.Stat(filepath.Join(dir, "mycli-"+alias+".txt")); !os.IsNotExist(err) {
+				t.Errorf("expected no stub page for the redirected alias %s, got: %v", alias, err)
+			}
+		}
+	})
+
+	t.Run("deprecated commands documented", func(t *testing.T) {
+		got := generate(t, filepath.Join(t.TempDir(), "redirects"), WithRedirectDefines("docs/mycli", ""), WithDeprecatedCommands())
+		checkStringOmits(t, got, "mycli-old/")
+	})
+
+	t.Run("existing file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "redirects")
+		existing := "define: prefix docs/mycli\n" +
+			"define: base https://example.com/${prefix}\n\n" +
+			"raw: ${prefix}/stable -> ${base}/current/\n" +
+			"raw: ${prefix}/command/mycli-say/ -> ${base}/command/mycli-echo/\n"
+		if err := os.WriteFile(filename, []byte(existing), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		expected := existing + "\n" +
+			"raw: ${prefix}/command/mycli-config/ -> ${base}/command/mycli/\n" +
+			"raw: ${prefix}/command/mycli-config-set/ -> ${base}/command/mycli/\n" +
+			"raw: ${prefix}/command/mycli-old/ -> ${base}/command/mycli/\n"
+		if got := generate(t, filename, WithRedirectDefines("ignored", "")); got != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+		}
+		if got := generate(t, filename); got != expected {
+			t.Errorf("expected the file to be unchanged, got:\n%s", got)
+		}
+	})
+
+	t.Run("missing defines", func(t *testing.T) {
+		err := GenTreeDocs(root, t.TempDir(), WithRedirects(filepath.Join(t.TempDir(), "redirects"), previous, nil))
+		if !errors.Is(err, ErrMissingRedirectDefine) {
+			t.Fatalf("expected ErrMissingRedirectDefine, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrUnsafeText = errors.New("text cannot be safely represented")
+	// markers that turn a line into a list, comment, directive or field list.
+	lineStartMarkupRegex = regexp.MustCompile(`^(\s*)([-+](\s|$)|#\.(\s|$)|\.\.|:|\d+[.)](\s|$)|[a-zA-Z][.)]\s)`)
+)
+
+// WithEscaping escapes the characters of the user-supplied text (command names, Short, Long,
+// argument descriptions, flag usages, defaults and version notes) that reStructuredText would interpret as markup.
+// With WithMarkdown, only the text outside of the converted Markdown is escaped, and only for
+// the substitution references and trailing underscores that Markdown doesn't use as markup.
+func WithEscaping() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.sanitizer = &sanitizer{}
+	}
+}
+
+// WithStrictEscaping behaves like WithEscaping but GenDocs returns ErrUnsafeText
+// for text that cannot be safely represented, such as control characters
+// or line breaks in command names and short descriptions.
+func WithStrictEscaping() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.sanitizer = &sanitizer{strict: true}
+	}
+}
+
+// EscapeRST escapes the characters of text that reStructuredText would interpret as markup.
+func EscapeRST(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = escapeLineStart(escapeInline(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func escapeInline(line string) string {
+	return escapeInlineChars(line, "\\*`|")
+}
+
+// escapeMarkdownText escapes the characters of Markdown text that are markup in reStructuredText only.
+func escapeMarkdownText(text string) string {
+	return escapeInlineChars(text, "|")
+}
+
+// escapeInlineChars escapes chars and the trailing underscores of line.
+func escapeInlineChars(line, chars string) string {
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case strings.IndexByte(chars, c) >= 0:
+			b.WriteByte('\\')
+		case c == '_':
+			// a trailing underscore makes the word a reference
+			if i+1 == len(line) || !isWordChar(line[i+1]) {
+				b.WriteByte('\\')
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func escapeLineStart(line string) string {
+	m := lineStartMarkupRegex.FindStringSubmatchIndex(line)
+	if m == nil {
+		return line
+	}
+	markup := line[m[4]:m[5]]
+	if markup[0] >= '0' && markup[0] <= '9' || unicode.IsLetter(rune(markup[0])) {
+		// escape the enumerator punctuation, e.g. 1\. item
+		i := strings.IndexAny(markup, ".)")
+		return line[:m[4]] + markup[:i] + "\\" + line[m[4]+i:]
+	}
+	return line[:m[4]] + "\\" + line[m[4]:]
+}
+
+// sanitizer escapes user-supplied text and, in strict mode, records the first unsafe text found.
+// A nil sanitizer leaves the text untouched.
+type sanitizer struct {
+	strict bool
+	unsafe error
+}
+
+// escape escapes text that may span several lines.
+func (s *sanitizer) escape(field, text string) string {
+	if s == nil {
+		return text
+	}
+	s.check(field, text, true)
+	return EscapeRST(text)
+}
+
+// escapeMarkdown converts Markdown text to reStructuredText, escaping the text outside of the converted markup.
+func (s *sanitizer) escapeMarkdown(field, text string) string {
+	if s == nil {
+		return MarkdownToRST(text)
+	}
+	s.check(field, text, true)
+	return markdownToRST(text, escapeMarkdownText)
+}
+
+// escapeLine escapes text that must fit in a single line.
+func (s *sanitizer) escapeLine(field, text string) string {
+	if s == nil {
+		return text
+	}
+	s.check(field, text, false)
+	return EscapeRST(text)
+}
+
+func (s *sanitizer) check(field, text string, multiline bool) {
+	if s == nil || !s.strict || s.unsafe != nil {
+		return
+	}
+	for _, r := range text {
+		if r == '\n' && !multiline {
+			s.unsafe = fmt.Errorf("%w: %s contains a line break", ErrUnsafeText, field)
+			return
+		}
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			s.unsafe = fmt.Errorf("%w: %s contains control character %U", ErrUnsafeText, field, r)
+			return
+		}
+	}
+}
+
+// err returns the first unsafe text found in strict mode.
+func (s *sanitizer) err() error {
+	if s == nil {
+		return nil
+	}
+	return s.unsafe
+}
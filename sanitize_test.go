@@ -0,0 +1,97 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestEscapeRST(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: "plain text", expected: "plain text"},
+		{text: "a *glob* | pipe", expected: `a \*glob\* \| pipe`},
+		{text: "use `quotes`", expected: "use \\`quotes\\`"},
+		{text: `C:\path`, expected: `C:\\path`},
+		{text: "word_ and snake_case", expected: `word\_ and snake_case`},
+		{text: "- not a list", expected: `\- not a list`},
+		{text: "first\n1. not enumerated", expected: "first\n1\\. not enumerated"},
+		{text: ".. not a comment", expected: `\.. not a comment`},
+		{text: ":not: a field", expected: `\:not: a field`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := EscapeRST(tt.text); got != tt.expected {
+				t.Errorf("expected: %s, got: %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenDocsEscaping(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		c := &cobra.Command{
+			Use:   "escape <name_>",
+			Short: "Matches *everything*",
+			Run:   emptyRun,
+			Annotations: map[string]string{
+				"name_Desc": "- the name",
+			},
+		}
+		c.Flags().String("pattern", "*", "| pattern to match")
+		return c
+	}
+
+	t.Run("escape", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := GenDocs(newCmd(), buf, WithEscaping()); err != nil {
+			t.Fatal(err)
+		}
+		output := buf.String()
+		checkStringContains(t, output, `Matches \*everything\*`)
+		checkStringContains(t, output, "   * - name\\_\n     - string\n     - true\n     - \\- the name\n")
+		checkStringContains(t, output, `\| pattern to match This value defaults to "\*".`)
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		c := newCmd()
+		c.Short = "Set my_var_ to a|b, see [docs](https://example.com/a_b_) and __bold__"
+		c.Long = "- keep the list\n- with `code|span_`"
+		buf := new(bytes.Buffer)
+		if err := GenDocs(c, buf, WithStrictEscaping(), WithMarkdown()); err != nil {
+			t.Fatal(err)
+		}
+		output := buf.String()
+		checkStringContains(t, output, "Set my_var\\_ to a\\|b, see `docs <https://example.com/a_b_>`__ and **bold**\n")
+		checkStringContains(t, output, "\n- keep the list\n- with ``code|span_``\n")
+		checkStringContains(t, output, `\| pattern to match This value defaults to "\*".`)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		c := newCmd()
+		c.Short = "Matches\neverything"
+		err := GenDocs(c, new(bytes.Buffer), WithStrictEscaping())
+		if !errors.Is(err, ErrUnsafeText) {
+			t.Fatalf("expected ErrUnsafeText, got %v", err)
+		}
+	})
+}
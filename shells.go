@@ -0,0 +1,189 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Shell is a command-line shell examples can be rendered for.
+type Shell struct {
+	// ID is the tab id, also used as key of Example.Shells.
+	ID string
+	// Name is the tab title.
+	Name string
+	// Language is the language of the code-block.
+	Language string
+	// Convert converts a POSIX shell example to this shell.
+	Convert func(posix string) string
+}
+
+var (
+	Bash       = Shell{ID: "bash", Name: "Bash", Language: "sh", Convert: func(posix string) string { return posix }}
+	PowerShell = Shell{ID: "powershell", Name: "PowerShell", Language: "powershell", Convert: ToPowerShell}
+	Cmd        = Shell{ID: "cmd", Name: "Windows Command Prompt", Language: "bat", Convert: ToCmd}
+	// DefaultShells are the shells used by WithShellTabs when none is given.
+	DefaultShells = []Shell{Bash, PowerShell, Cmd}
+)
+
+var placeholderRegex = regexp.MustCompile(`^<[^<>]+>$`)
+
+// WithShellTabs renders every example as a tabs block with one tab per shell.
+// Examples are converted from POSIX shell syntax unless a variant is given in Example.Shells.
+func WithShellTabs(shells ...Shell) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.exampleFormatter = ShellTabsExampleFormatter(shells...)
+	}
+}
+
+// ToPowerShell converts the quoting and line continuations of a POSIX shell example to PowerShell.
+func ToPowerShell(posix string) string {
+	return convertShell(posix, "`", "#", "#", quotePowerShell)
+}
+
+// ToCmd converts the quoting, line continuations and comments of a POSIX shell example to cmd.exe.
+func ToCmd(posix string) string {
+	// REM is only a comment at the start of a command
+	return convertShell(posix, "^", "REM", "& REM", quoteCmd)
+}
+
+// convertShell converts posix line by line, starting comment lines with comment
+// and trailing comments with inlineComment.
+func convertShell(posix, continuation, comment, inlineComment string, quote func(string) string) string {
+	lines := strings.Split(posix, "\n")
+	for i, line := range lines {
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		body := strings.TrimRight(line[len(indent):], " \t")
+		if rest, ok := strings.CutPrefix(body, "#"); ok {
+			lines[i] = indent + comment + rest
+			continue
+		}
+		suffix := ""
+		if strings.HasSuffix(body, "\\") {
+			body = strings.TrimSuffix(body, "\\")
+			suffix = " " + continuation
+		}
+		tokens, err := shellTokens(body)
+		if err != nil {
+			// keep what can't be parsed as is
+			continue
+		}
+		words := make([]string, 0, len(tokens))
+		for _, token := range tokens {
+			switch token.kind {
+			case wordToken:
+				words = append(words, quote(token.value))
+			case commentToken:
+				words = append(words, inlineComment+strings.TrimPrefix(token.value, "#"))
+			case operatorToken:
+				words = append(words, token.value)
+			}
+		}
+		lines[i] = strings.TrimRight(indent+strings.Join(words, " ")+suffix, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func quotePowerShell(word string) string {
+	if word != "" && (placeholderRegex.MatchString(word) || !strings.ContainsAny(word, " \t'\"`$;|&(){}@#<>,")) {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", "''") + "'"
+}
+
+func quoteCmd(word string) string {
+	if word != "" && (placeholderRegex.MatchString(word) || !strings.ContainsAny(word, " \t\"&|<>^()")) {
+		return word
+	}
+	return `"` + strings.ReplaceAll(word, `"`, `\"`) + `"`
+}
+
+type shellTokenKind int
+
+const (
+	wordToken shellTokenKind = iota
+	operatorToken
+	commentToken
+)
+
+type shellToken struct {
+	kind  shellTokenKind
+	value string
+}
+
+// shellTokens splits a POSIX shell command line in unquoted words, operators and comments.
+func shellTokens(line string) ([]shellToken, error) {
+	var tokens []shellToken
+	var current strings.Builder
+	inWord := false
+	var quote byte
+	endWord := func() {
+		if inWord {
+			tokens = append(tokens, shellToken{kind: wordToken, value: current.String()})
+			current.Reset()
+			inWord = false
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				current.WriteByte(c)
+			}
+		case quote == '"':
+			switch {
+			case c == '"':
+				quote = 0
+			case c == '\\' && i+1 < len(line) && strings.IndexByte("\"\\$`", line[i+1]) >= 0:
+				i++
+				current.WriteByte(line[i])
+			default:
+				current.WriteByte(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == '\\' && i+1 < len(line):
+			i++
+			current.WriteByte(line[i])
+			inWord = true
+		case c == ' ' || c == '\t':
+			endWord()
+		case !inWord && c == '#':
+			tokens = append(tokens, shellToken{kind: commentToken, value: line[i:]})
+			return tokens, nil
+		case !inWord && strings.IndexByte("|;&>", c) >= 0:
+			j := i
+			for j < len(line) && strings.IndexByte("|;&>", line[j]) >= 0 {
+				j++
+			}
+			tokens = append(tokens, shellToken{kind: operatorToken, value: line[i:j]})
+			i = j - 1
+		default:
+			current.WriteByte(c)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnterminatedQuote, line)
+	}
+	endWord()
+	return tokens, nil
+}
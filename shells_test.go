@@ -0,0 +1,58 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"testing"
+)
+
+func TestShellConversion(t *testing.T) {
+	posix := `# Create a user
+mycli users create <name> \
+  --role 'read write' --filter "{\"a\": 1}" | jq .`
+
+	t.Run("powershell", func(t *testing.T) {
+		expected := "# Create a user\n" +
+			"mycli users create <name> `\n" +
+			`  --role 'read write' --filter '{"a": 1}' | jq .`
+		if got := ToPowerShell(posix); got != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+		}
+	})
+
+	t.Run("cmd", func(t *testing.T) {
+		expected := "REM Create a user\n" +
+			"mycli users create <name> ^\n" +
+			`  --role "read write" --filter "{\"a\": 1}" | jq .`
+		if got := ToCmd(posix); got != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+		}
+	})
+
+	t.Run("inline comment", func(t *testing.T) {
+		if got, expected := ToCmd("mycli a --y x # c"), "mycli a --y x & REM c"; got != expected {
+			t.Errorf("expected: %s, got: %s", expected, got)
+		}
+		if got, expected := ToPowerShell("mycli a --y x # c"), "mycli a --y x # c"; got != expected {
+			t.Errorf("expected: %s, got: %s", expected, got)
+		}
+	})
+
+	t.Run("unparsable", func(t *testing.T) {
+		if got := ToCmd(`mycli "unterminated`); got != `mycli "unterminated` {
+			t.Errorf("expected line to be kept, got: %s", got)
+		}
+	})
+}
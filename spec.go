@@ -0,0 +1,166 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Spec describes the surface of a command tree: its commands, arguments and flags.
+// It can be stored as JSON to compare releases, see Diff.
+type Spec struct {
+	Commands []CommandSpec `json:"commands"`
+}
+
+// CommandSpec describes a single command of a Spec.
+type CommandSpec struct {
+	// Path is the full command path, for example "mycli echo".
+	Path       string     `json:"path"`
+	Short      string     `json:"short,omitempty"`
+	Aliases    []string   `json:"aliases,omitempty"`
+	Deprecated string     `json:"deprecated,omitempty"`
+	Runnable   bool       `json:"runnable,omitempty"`
+	Args       []ArgSpec  `json:"args,omitempty"`
+	Flags      []FlagSpec `json:"flags,omitempty"`
+}
+
+// ArgSpec describes a positional argument of a command, as found in its Use line.
+type ArgSpec struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// FlagSpec describes a visible flag defined by a command.
+type FlagSpec struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+	Type      string `json:"type"`
+	Default   string `json:"default,omitempty"`
+	Usage     string `json:"usage,omitempty"`
+	Required  bool   `json:"required,omitempty"`
+	// Persistent is true when the flag is inherited by the subcommands.
+	Persistent bool   `json:"persistent,omitempty"`
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+// NewSpec describes root and its documented subcommands, deprecated ones included.
+// Hidden commands and flags are left out, except deprecated flags which pflag hides.
+func NewSpec(root *cobra.Command) *Spec {
+	return newSpec(root, (&GenDocsOptions{deprecatedCommands: true}).isDocumented)
+}
+
+// newSpec describes root and the subcommands documented reports.
+func newSpec(root *cobra.Command, documented func(cmd *cobra.Command) bool) *Spec {
+	spec := &Spec{}
+	var visit func(cmd *cobra.Command)
+	visit = func(cmd *cobra.Command) {
+		spec.Commands = append(spec.Commands, newCommandSpec(cmd))
+		for _, c := range cmd.Commands() {
+			if documented(c) {
+				visit(c)
+			}
+		}
+	}
+	visit(root)
+	sort.Slice(spec.Commands, func(i, j int) bool { return spec.Commands[i].Path < spec.Commands[j].Path })
+	return spec
+}
+
+func newCommandSpec(cmd *cobra.Command) CommandSpec {
+	cmd.InitDefaultHelpFlag()
+	spec := CommandSpec{
+		Path:       cmd.CommandPath(),
+		Short:      cmd.Short,
+		Aliases:    cmd.Aliases,
+		Deprecated: cmd.Deprecated,
+		Runnable:   cmd.Runnable(),
+	}
+	for _, a := range argsRegex.FindAllString(cmd.Use, -1) {
+		spec.Args = append(spec.Args, ArgSpec{Name: a[1 : len(a)-1], Required: a[0] == '<'})
+	}
+	cmd.LocalNonPersistentFlags().VisitAll(func(flag *pflag.Flag) {
+		if isSpecFlag(flag) {
+			spec.Flags = append(spec.Flags, newFlagSpec(flag, false))
+		}
+	})
+	cmd.PersistentFlags().VisitAll(func(flag *pflag.Flag) {
+		if isSpecFlag(flag) {
+			spec.Flags = append(spec.Flags, newFlagSpec(flag, true))
+		}
+	})
+	sort.Slice(spec.Flags, func(i, j int) bool { return spec.Flags[i].Name < spec.Flags[j].Name })
+	return spec
+}
+
+// isSpecFlag reports whether flag is part of the spec: deprecated flags still work,
+// even though MarkDeprecated hides them.
+func isSpecFlag(flag *pflag.Flag) bool {
+	return !flag.Hidden || flag.Deprecated != ""
+}
+
+func newFlagSpec(flag *pflag.Flag, persistent bool) FlagSpec {
+	_, required := flag.Annotations[cobra.BashCompOneRequiredFlag]
+	return FlagSpec{
+		Name:       flag.Name,
+		Shorthand:  flag.Shorthand,
+		Type:       flag.Value.Type(),
+		Default:    flag.DefValue,
+		Usage:      flag.Usage,
+		Required:   required,
+		Persistent: persistent,
+		Deprecated: flag.Deprecated,
+	}
+}
+
+// Command returns the spec of the command with the given path, or nil.
+func (s *Spec) Command(path string) *CommandSpec {
+	for i := range s.Commands {
+		if s.Commands[i].Path == path {
+			return &s.Commands[i]
+		}
+	}
+	return nil
+}
+
+// Flag returns the spec of the flag with the given name defined by the command, or nil.
+func (c *CommandSpec) Flag(name string) *FlagSpec {
+	for i := range c.Flags {
+		if c.Flags[i].Name == name {
+			return &c.Flags[i]
+		}
+	}
+	return nil
+}
+
+// WriteSpec writes spec as indented JSON.
+func WriteSpec(w io.Writer, spec *Spec) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(spec)
+}
+
+// ReadSpec reads a spec written by WriteSpec.
+func ReadSpec(r io.Reader) (*Spec, error) {
+	spec := &Spec{}
+	if err := json.NewDecoder(r).Decode(spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
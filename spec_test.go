@@ -0,0 +1,69 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestNewSpec(t *testing.T) {
+	spec := NewSpec(currentTree())
+
+	paths := make([]string, 0, len(spec.Commands))
+	for _, c := range spec.Commands {
+		paths = append(paths, c.Path)
+	}
+	if expected := []string{"mycli", "mycli config", "mycli config set", "mycli echo", "mycli new"}; !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("expected commands %v, got %v", expected, paths)
+	}
+
+	echo := spec.Command("mycli echo")
+	expected := CommandSpec{
+		Path:     "mycli echo",
+		Short:    "Echo text",
+		Aliases:  []string{"say"},
+		Runnable: true,
+		Args:     []ArgSpec{{Name: "text", Required: true}},
+		Flags: []FlagSpec{
+			{Name: "color", Type: "string", Usage: "output color", Required: true},
+			{Name: "help", Shorthand: "h", Type: "bool", Default: "false", Usage: "help for echo"},
+			{Name: "times", Type: "int", Default: "2", Usage: "repetitions"},
+			{Name: "uppercase", Shorthand: "u", Type: "bool", Default: "false", Usage: "upper case output"},
+		},
+	}
+	if !reflect.DeepEqual(*echo, expected) {
+		t.Errorf("expected:\n%+v\ngot:\n%+v", expected, *echo)
+	}
+	if flag := spec.Command("mycli").Flag("profile"); flag == nil || !flag.Persistent {
+		t.Errorf("expected persistent profile flag, got: %+v", flag)
+	}
+}
+
+func TestSpecRoundTrip(t *testing.T) {
+	spec := NewSpec(previousTree())
+	buf := new(bytes.Buffer)
+	if err := WriteSpec(buf, spec); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadSpec(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, spec) {
+		t.Errorf("expected:\n%+v\ngot:\n%+v", spec, got)
+	}
+}
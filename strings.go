@@ -14,6 +14,8 @@
 
 package cobra2snooty
 
+import "strings"
+
 // adapted from: https://github.com/kr/text/blob/main/indent.go
 func indentString(s, p string) string {
 	var res []byte
@@ -29,3 +31,12 @@ func indentString(s, p string) string {
 	}
 	return string(res)
 }
+
+// indentContinuation indents every line of s but the first one.
+func indentContinuation(s, p string) string {
+	first, rest, found := strings.Cut(s, "\n")
+	if !found {
+		return s
+	}
+	return first + "\n" + indentString(rest, p)
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// PageStyle controls the preamble, the headings and the labels of the generated pages.
+type PageStyle struct {
+	// Domain is the default domain of the page, no default-domain directive is written when empty.
+	Domain string
+	// ContentsDepth is the depth of the contents directive, no contents directive is written when 0.
+	ContentsDepth int
+	// ContentsClass is the class of the contents directive, if any.
+	ContentsClass string
+	// TitleAdornment is the character underlining the page title, "=" when empty.
+	TitleAdornment string
+	// TitleOverline also overlines the page title with TitleAdornment.
+	TitleOverline bool
+	// SectionAdornment is the character underlining the sections, "-" when empty.
+	SectionAdornment string
+	// SubsectionAdornment is the character underlining the subsections, "~" when empty.
+	SubsectionAdornment string
+	// LabelPrefix is prepended to the labels of the pages, for example "cli-".
+	LabelPrefix string
+	// LabelSeparator replaces the spaces of the command path in the labels of the pages, "-" when empty.
+	LabelSeparator string
+}
+
+// MongoDBPageStyle is the default style, matching the MongoDB documentation.
+var MongoDBPageStyle = PageStyle{
+	Domain:              "mongodb",
+	ContentsDepth:       1,
+	ContentsClass:       "singlecol",
+	TitleAdornment:      "=",
+	TitleOverline:       true,
+	SectionAdornment:    "-",
+	SubsectionAdornment: "~",
+	LabelSeparator:      separator,
+}
+
+// WithPageStyle sets the style of the generated pages, MongoDBPageStyle by default.
+func WithPageStyle(style PageStyle) func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.pageStyle = style
+	}
+}
+
+// preamble returns the directives following the page title.
+func (s PageStyle) preamble() string {
+	preamble := ""
+	if s.Domain != "" {
+		preamble += "\n.. default-domain:: " + s.Domain + "\n"
+	}
+	if s.ContentsDepth > 0 {
+		preamble += fmt.Sprintf("\n.. contents:: On this page\n   :local:\n   :backlinks: none\n   :depth: %d\n", s.ContentsDepth)
+		if s.ContentsClass != "" {
+			preamble += "   :class: " + s.ContentsClass + "\n"
+		}
+	}
+	return preamble
+}
+
+// title returns the page title with its adornments.
+func (s PageStyle) title(title string) string {
+	adornment := strings.Repeat(orDefault(s.TitleAdornment, "="), len(title)) + "\n"
+	if s.TitleOverline {
+		return adornment + title + "\n" + adornment
+	}
+	return title + "\n" + adornment
+}
+
+// section returns the heading of a section.
+func (s PageStyle) section(title string) string {
+	return title + "\n" + strings.Repeat(orDefault(s.SectionAdornment, "-"), len(title)) + "\n"
+}
+
+// subsection returns the heading of a subsection.
+func (s PageStyle) subsection(title string) string {
+	return title + "\n" + strings.Repeat(orDefault(s.SubsectionAdornment, "~"), len(title)) + "\n"
+}
+
+// label returns the label of the page of the command with the given path.
+func (s PageStyle) label(path string) string {
+	return s.LabelPrefix + strings.ReplaceAll(path, " ", orDefault(s.LabelSeparator, separator))
+}
+
+// FlagLabel returns the label of a flag on the page of cmd with this style, see FlagLabel.
+func (s PageStyle) FlagLabel(cmd *cobra.Command, name string) string {
+	return s.label(cmd.CommandPath()) + "-option-" + name
+}
+
+// program returns the program of the option directives of cmd: the command path readers type,
+// while the labels keep the page name.
+func (s PageStyle) program(cmd *cobra.Command) string {
+	return cmd.CommandPath()
+}
+
+// FlagRef returns an :option: role referencing a flag on the page of cmd with this style, see FlagRef.
+func (s PageStyle) FlagRef(cmd *cobra.Command, name string) string {
+	return fmt.Sprintf(":option:`%s --%s`", s.program(cmd), name)
+}
+
+func orDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
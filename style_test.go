@@ -0,0 +1,183 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestPageStylePreamble(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    PageStyle
+		expected string
+	}{
+		{
+			name:  "mongodb",
+			style: MongoDBPageStyle,
+			expected: `
+.. default-domain:: mongodb
+
+.. contents:: On this page
+   :local:
+   :backlinks: none
+   :depth: 1
+   :class: singlecol
+`,
+		},
+		{
+			name:     "no domain",
+			style:    PageStyle{ContentsDepth: 2},
+			expected: "\n.. contents:: On this page\n   :local:\n   :backlinks: none\n   :depth: 2\n",
+		},
+		{
+			name:     "no contents",
+			style:    PageStyle{Domain: "std"},
+			expected: "\n.. default-domain:: std\n",
+		},
+		{
+			name:  "none",
+			style: PageStyle{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.preamble(); got != tt.expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenDocsPageStyle(t *testing.T) {
+	root := &cobra.Command{Use: "mycli", Short: "My CLI", Long: "See mycli echo.", Run: emptyRun}
+	root.Flags().Bool("debug", false, "debug output")
+	root.AddCommand(&cobra.Command{Use: "echo", Short: "Echo anything", Run: emptyRun})
+	root.Annotations = map[string]string{"toc": "true"}
+
+	style := PageStyle{
+		TitleAdornment:      "#",
+		SectionAdornment:    "=",
+		SubsectionAdornment: "-",
+		LabelPrefix:         "cli-",
+		LabelSeparator:      "_",
+	}
+	buf := new(bytes.Buffer)
+	if err := GenDocs(root, buf, WithoutDate(), WithAutoLinks(), WithPageStyle(style)); err != nil {
+		t.Fatal(err)
+	}
+	expected := `.. _cli-mycli:
+
+mycli
+#####
+
+My CLI
+
+See :ref:` + "`mycli echo <cli-mycli_echo>`" + `.
+
+Syntax
+======
+
+.. code-block::
+   :caption: Command Syntax
+
+   mycli [options]
+
+.. Code end marker, please don't delete this comment
+
+Options
+=======
+
+.. list-table::
+   :header-rows: 1
+   :widths: 20 10 10 60
+
+   * - Name
+     - Type
+     - Required
+     - Description
+   * - --debug
+     - 
+     - false
+     - debug output
+   * - -h, --help
+     - 
+     - false
+     - help for mycli
+
+Related Commands
+================
+
+* :ref:` + "`cli-mycli_echo`" + ` - Echo anything
+
+
+.. toctree::
+   :titlesonly:
+
+   echo </command/mycli-echo>
+
+*Auto generated by cobra2snooty*
+`
+	if got := buf.String(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestPageStyleFlagLabel(t *testing.T) {
+	root := &cobra.Command{Use: "mycli"}
+	echo := &cobra.Command{Use: "echo", Short: "Echo anything", Run: emptyRun}
+	echo.Flags().String("name", "", "name to echo")
+	root.AddCommand(echo)
+
+	style := PageStyle{LabelPrefix: "cli-"}
+	buf := new(bytes.Buffer)
+	if err := GenDocs(echo, buf, WithoutDate(), WithOptionDirectives(), WithPageStyle(style)); err != nil {
+		t.Fatal(err)
+	}
+	label := style.FlagLabel(echo, "name")
+	if label != "cli-mycli-echo-option-name" {
+		t.Errorf("unexpected label: %s", label)
+	}
+	checkStringContains(t, buf.String(), ".. _"+label+":\n")
+	if got := FlagLabel(echo, "name"); got != "mycli-echo-option-name" {
+		t.Errorf("unexpected default label: %s", got)
+	}
+}
+
+func TestPageStyleFlagRef(t *testing.T) {
+	root := &cobra.Command{Use: "mycli"}
+	echo := &cobra.Command{Use: "echo", Short: "Echo anything", Long: "Set --name to echo a name.", Run: emptyRun}
+	echo.Flags().String("name", "", "name to echo")
+	root.AddCommand(echo)
+
+	style := PageStyle{LabelPrefix: "cli-", LabelSeparator: "_"}
+	buf := new(bytes.Buffer)
+	if err := GenDocs(echo, buf, WithoutDate(), WithOptionDirectives(), WithAutoLinks(), WithPageStyle(style)); err != nil {
+		t.Fatal(err)
+	}
+	ref := style.FlagRef(echo, "name")
+	if ref != ":option:`mycli echo --name`" {
+		t.Errorf("unexpected ref: %s", ref)
+	}
+	// the references of the text match the program of the option directives
+	checkStringContains(t, buf.String(), "Set "+ref+" to echo a name.")
+	checkStringContains(t, buf.String(), ".. program:: mycli echo\n")
+	if got := FlagRef(echo, "name"); got != ref {
+		t.Errorf("unexpected default ref: %s", got)
+	}
+}
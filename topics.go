@@ -0,0 +1,71 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// WithHelpTopics documents additional help topic commands, see cobra.Command.IsAdditionalHelpTopicCommand,
+// as concept pages without Syntax, Arguments, Options, Output and Examples sections,
+// listed in the Additional Topics section and the toctree of their parent.
+func WithHelpTopics() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.helpTopics = true
+	}
+}
+
+// isHelpTopic reports whether cmd is documented as a concept page.
+func (o *GenDocsOptions) isHelpTopic(cmd *cobra.Command) bool {
+	return o.helpTopics && cmd.IsAdditionalHelpTopicCommand()
+}
+
+// helpTopicCommands returns the documented help topic subcommands of cmd sorted by name.
+func (o *GenDocsOptions) helpTopicCommands(cmd *cobra.Command) []*cobra.Command {
+	var topics []*cobra.Command
+	for _, c := range cmd.Commands() {
+		if o.isHelpTopic(c) {
+			topics = append(topics, c)
+		}
+	}
+	sort.Sort(byName(topics))
+	return topics
+}
+
+// hasToctree reports whether the page of cmd gets a toctree: commands grouping other commands
+// and pages with help topics get one.
+func (o *GenDocsOptions) hasToctree(cmd *cobra.Command) bool {
+	if o.isHelpTopic(cmd) {
+		return len(o.helpTopicCommands(cmd)) > 0
+	}
+	_, ok := cmd.Annotations["toc"]
+	return ok || !cmd.Runnable() || len(o.helpTopicCommands(cmd)) > 0
+}
+
+func printAdditionalTopics(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) {
+	topics := options.helpTopicCommands(cmd)
+	if len(topics) == 0 {
+		return
+	}
+	buf.WriteString(options.pageStyle.section("Additional Topics") + "\n")
+	for _, topic := range topics {
+		_, _ = fmt.Fprintf(buf, "* :ref:`%s` - %s\n", options.label(topic), options.formatText(topic, topic.Short))
+	}
+	buf.WriteString("\n")
+}
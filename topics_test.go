@@ -0,0 +1,82 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func helpTopicsTree() *cobra.Command {
+	root := &cobra.Command{Use: "mycli", Short: "My CLI", Run: emptyRun}
+	root.Flags().Bool("debug", false, "debug output")
+	root.AddCommand(
+		&cobra.Command{Use: "echo", Short: "Echo anything", Run: emptyRun},
+		&cobra.Command{Use: "environment", Short: "Environment variables", Long: "Set MYCLI_PROFILE to pick a profile."},
+		&cobra.Command{Use: "config-file", Short: "Configuration file"},
+	)
+	return root
+}
+
+func TestHelpTopics(t *testing.T) {
+	t.Run("skipped by default", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := GenTreeDocs(helpTopicsTree(), dir, WithoutDate()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "mycli-environment.txt")); !os.IsNotExist(err) {
+			t.Fatalf("expected no page for the help topic, got: %v", err)
+		}
+		b, err := os.ReadFile(filepath.Join(dir, "mycli.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkStringOmits(t, string(b), "Additional Topics")
+		checkStringOmits(t, string(b), ".. toctree::")
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := GenTreeDocs(helpTopicsTree(), dir, WithoutDate(), WithHelpTopics()); err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(filepath.Join(dir, "mycli.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		root := string(b)
+		checkStringContains(t, root, "Related Commands\n----------------\n\n* :ref:`mycli-echo` - Echo anything\n\n")
+		checkStringContains(t, root, "Additional Topics\n-----------------\n\n"+
+			"* :ref:`mycli-config-file` - Configuration file\n"+
+			"* :ref:`mycli-environment` - Environment variables\n\n")
+		checkStringContains(t, root, ".. toctree::\n   :titlesonly:\n\n"+
+			"   config-file </command/mycli-config-file>\n"+
+			"   echo </command/mycli-echo>\n"+
+			"   environment </command/mycli-environment>\n")
+
+		b, err = os.ReadFile(filepath.Join(dir, "mycli-environment.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		topic := string(b)
+		checkStringContains(t, topic, "Environment variables\n\nSet MYCLI_PROFILE to pick a profile.\n")
+		for _, section := range []string{"Syntax", "Options", "Output", "Examples", ".. toctree::"} {
+			checkStringOmits(t, topic, section)
+		}
+	})
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const mutuallyExclusiveAnnotation = "cobra_annotation_mutually_exclusive"
+
+var (
+	ErrUnknownCommand         = errors.New("unknown command")
+	ErrUnknownFlag            = errors.New("unknown flag")
+	ErrMissingRequiredFlag    = errors.New("missing required flag")
+	ErrMutuallyExclusiveFlags = errors.New("mutually exclusive flags")
+	ErrUnterminatedQuote      = errors.New("unterminated quote")
+)
+
+// ExampleError reports an example that doesn't match the command tree.
+type ExampleError struct {
+	// Command is the path of the command documenting the example.
+	Command string
+	// Example is the command line of the example.
+	Example string
+	Err     error
+}
+
+func (e *ExampleError) Error() string {
+	return fmt.Sprintf("%s: example %q: %v", e.Command, e.Example, e.Err)
+}
+
+func (e *ExampleError) Unwrap() error {
+	return e.Err
+}
+
+// WithExampleValidation makes GenDocs and GenTreeDocs fail when the examples of a command
+// don't match the command tree, see ValidateExamples.
+func WithExampleValidation() func(options *GenDocsOptions) {
+	return func(options *GenDocsOptions) {
+		options.validateExamples = true
+	}
+}
+
+// ValidateTreeExamples validates the examples of cmd and all its available subcommands.
+func ValidateTreeExamples(cmd *cobra.Command) error {
+	errs := []error{ValidateExamples(cmd)}
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		errs = append(errs, ValidateTreeExamples(c))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateExamples resolves the command lines found in the examples of cmd against its root command,
+// without executing them, and reports unknown commands, unknown flags, missing required flags and
+// mutually exclusive flags used together. Every error is an *ExampleError.
+func ValidateExamples(cmd *cobra.Command) error {
+	lines, err := exampleCommandLines(cmd)
+	if err != nil {
+		return err
+	}
+	errs := make([]error, 0, len(lines))
+	for _, line := range lines {
+		if err := ValidateExample(cmd.Root(), line); err != nil {
+			errs = append(errs, &ExampleError{Command: cmd.CommandPath(), Example: line, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateExample resolves a single command line against the tree of root without executing it.
+func ValidateExample(root *cobra.Command, line string) error {
+	args, err := splitCommandLine(line)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 || args[0] != root.Name() {
+		return fmt.Errorf("%w: %s", ErrUnknownCommand, line)
+	}
+
+	cmd, rest, err := root.Find(args[1:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnknownCommand, err)
+	}
+	cmd.InitDefaultHelpFlag()
+
+	set, positional, err := exampleFlags(cmd, rest)
+	if err != nil {
+		return err
+	}
+	if unknownSubcommand(cmd, positional) {
+		return fmt.Errorf("%w: %q for %q", ErrUnknownCommand, positional[0], cmd.CommandPath())
+	}
+
+	var errs []error
+	visitCommandFlags(cmd, func(flag *pflag.Flag) {
+		if _, required := flag.Annotations[cobra.BashCompOneRequiredFlag]; required && !set[flag.Name] {
+			errs = append(errs, fmt.Errorf("%w: --%s", ErrMissingRequiredFlag, flag.Name))
+		}
+		for _, group := range flag.Annotations[mutuallyExclusiveAnnotation] {
+			var used []string
+			for _, name := range strings.Fields(group) {
+				if set[name] {
+					used = append(used, "--"+name)
+				}
+			}
+			// report each group once, from its first flag
+			if len(used) > 1 && strings.Fields(group)[0] == flag.Name {
+				errs = append(errs, fmt.Errorf("%w: %s", ErrMutuallyExclusiveFlags, strings.Join(used, ", ")))
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// visitCommandFlags visits the local and inherited flags of cmd in lexicographical order.
+func visitCommandFlags(cmd *cobra.Command, fn func(*pflag.Flag)) {
+	seen := map[string]bool{}
+	var flags []*pflag.Flag
+	collect := func(flag *pflag.Flag) {
+		if !seen[flag.Name] {
+			seen[flag.Name] = true
+			flags = append(flags, flag)
+		}
+	}
+	cmd.Flags().VisitAll(collect)
+	cmd.InheritedFlags().VisitAll(collect)
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	for _, flag := range flags {
+		fn(flag)
+	}
+}
+
+// exampleValue stands for the value of a flag while parsing examples, so the command flags aren't set.
+type exampleValue struct {
+	typ string
+}
+
+func (v *exampleValue) String() string   { return "" }
+func (v *exampleValue) Set(string) error { return nil }
+func (v *exampleValue) Type() string     { return v.typ }
+
+// exampleFlags parses args like cmd.ParseFlags, with a copy of the local and inherited flags of cmd,
+// and returns the names of the flags set and the positional arguments.
+func exampleFlags(cmd *cobra.Command, args []string) (map[string]bool, []string, error) {
+	flags := pflag.NewFlagSet(cmd.Name(), pflag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.SetNormalizeFunc(cmd.Flags().GetNormalizeFunc())
+	flags.ParseErrorsAllowlist = pflag.ParseErrorsAllowlist(cmd.FParseErrWhitelist)
+	visitCommandFlags(cmd, func(flag *pflag.Flag) {
+		flagCopy := *flag
+		flagCopy.Value = &exampleValue{typ: flag.Value.Type()}
+		flags.AddFlag(&flagCopy)
+	})
+	if err := flags.Parse(args); err != nil {
+		var notExist *pflag.NotExistError
+		if errors.As(err, &notExist) {
+			return nil, nil, fmt.Errorf("%w: %w", ErrUnknownFlag, err)
+		}
+		return nil, nil, err
+	}
+	set := map[string]bool{}
+	flags.Visit(func(flag *pflag.Flag) {
+		set[flag.Name] = true
+	})
+	return set, flags.Args(), nil
+}
+
+// unknownSubcommand reports whether the first positional argument of a command with subcommands
+// is a mistyped subcommand rather than an argument the command accepts.
+func unknownSubcommand(cmd *cobra.Command, positional []string) bool {
+	if len(positional) == 0 || !cmd.HasAvailableSubCommands() {
+		return false
+	}
+	if !cmd.Runnable() || cmd.Args != nil && cmd.ValidateArgs(positional) != nil {
+		return true
+	}
+	if len(cmd.ValidArgs) > 0 {
+		return !slices.ContainsFunc(cmd.ValidArgs, func(arg string) bool {
+			// valid args may be followed by a tab and a description
+			name, _, _ := strings.Cut(arg, "\t")
+			return name == positional[0]
+		})
+	}
+	return cmd.Args == nil && !argsRegex.MatchString(cmd.Use)
+}
+
+// exampleCommandLines returns the command lines starting with the root command name
+// found in the structured examples of cmd or, when there are none, in cmd.Example.
+func exampleCommandLines(cmd *cobra.Command) ([]string, error) {
+	examples, err := Examples(cmd)
+	if err != nil {
+		return nil, err
+	}
+	texts := make([]string, 0, len(examples))
+	for _, example := range examples {
+		texts = append(texts, example.Command)
+	}
+	if len(texts) == 0 && cmd.Example != "" {
+		texts = append(texts, cmd.Example)
+	}
+
+	rootName := cmd.Root().Name()
+	var lines []string
+	for _, text := range texts {
+		for _, line := range joinContinuations(text) {
+			line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "$ "))
+			if line == rootName || strings.HasPrefix(line, rootName+" ") {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines, nil
+}
+
+// joinContinuations splits text in lines, joining the lines ending with a backslash.
+func joinContinuations(text string) []string {
+	var lines []string
+	current := ""
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimRight(line, " \t")
+		if strings.HasSuffix(trimmed, "\\") {
+			current += strings.TrimSuffix(trimmed, "\\") + " "
+			continue
+		}
+		lines = append(lines, current+line)
+		current = ""
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// splitCommandLine splits a POSIX shell command line in arguments, stopping at comments,
+// pipes and command separators.
+func splitCommandLine(line string) ([]string, error) {
+	tokens, err := shellTokens(line)
+	if err != nil {
+		return nil, err
+	}
+	var args []string
+	for _, token := range tokens {
+		if token.kind != wordToken {
+			break
+		}
+		args = append(args, token.value)
+	}
+	return args, nil
+}
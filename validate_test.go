@@ -0,0 +1,116 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func validationTree() *cobra.Command {
+	root := &cobra.Command{Use: "mycli"}
+	root.PersistentFlags().String("projectId", "", "project")
+
+	users := &cobra.Command{Use: "users"}
+	create := &cobra.Command{Use: "create <name>", Run: emptyRun}
+	create.Flags().String("role", "", "role")
+	create.Flags().String("password", "", "password")
+	create.Flags().Bool("generate", false, "generate a password")
+	create.Flags().BoolP("force", "f", false, "force")
+	create.Flags().BoolP("quiet", "q", false, "quiet")
+	_ = create.MarkFlagRequired("role")
+	create.MarkFlagsMutuallyExclusive("password", "generate")
+
+	users.AddCommand(create)
+	projects := &cobra.Command{Use: "projects", Run: emptyRun}
+	projects.AddCommand(&cobra.Command{Use: "list", Run: emptyRun})
+	clusters := &cobra.Command{Use: "clusters [name]", Run: emptyRun}
+	clusters.AddCommand(&cobra.Command{Use: "list", Run: emptyRun})
+	root.AddCommand(users, projects, clusters)
+	return root
+}
+
+func TestValidateExample(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected error
+	}{
+		{name: "valid", line: "mycli users create alice --role admin --projectId 123 -f"},
+		{name: "valid with quotes", line: `mycli users create "alice smith" --role='read write' # comment --unknown`},
+		{name: "valid with pipe", line: "mycli users create alice --role admin | jq --unknown"},
+		{name: "flag value with equals", line: "mycli users create alice --role=admin"},
+		{name: "bool flag before argument", line: "mycli users create --generate alice --role admin"},
+		{name: "shorthand cluster", line: "mycli users create alice -fq --role admin"},
+		{name: "argument of runnable parent", line: "mycli clusters prod"},
+		{name: "unknown command", line: "mycli accounts list", expected: ErrUnknownCommand},
+		{name: "unknown subcommand of runnable parent", line: "mycli projects lsit", expected: ErrUnknownCommand},
+		{name: "unknown subcommand", line: "mycli users delete alice", expected: ErrUnknownCommand},
+		{name: "unknown flag", line: "mycli users create alice --role admin --name bob", expected: ErrUnknownFlag},
+		{name: "unknown shorthand", line: "mycli users create alice --role admin -x", expected: ErrUnknownFlag},
+		{name: "missing required flag", line: "mycli users create alice", expected: ErrMissingRequiredFlag},
+		{name: "mutually exclusive", line: "mycli users create alice --role admin --password x --generate", expected: ErrMutuallyExclusiveFlags},
+		{name: "unterminated quote", line: `mycli users create "alice`, expected: ErrUnterminatedQuote},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateExample(validationTree(), tt.line)
+			if tt.expected == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidateTreeExamples(t *testing.T) {
+	root := validationTree()
+	create, _, _ := root.Find([]string{"users", "create"})
+	create.Example = `# Create a user
+mycli users create alice \
+  --role admin
+# Create a user with a removed flag
+$ mycli users create alice --role admin --admin
+`
+	err := ValidateTreeExamples(root)
+	var exampleErr *ExampleError
+	if !errors.As(err, &exampleErr) {
+		t.Fatalf("expected an ExampleError, got %v", err)
+	}
+	if exampleErr.Command != "mycli users create" || !errors.Is(exampleErr, ErrUnknownFlag) {
+		t.Fatalf("unexpected error: %v", exampleErr)
+	}
+	if err := GenDocs(create, io.Discard, WithExampleValidation()); !errors.Is(err, ErrUnknownFlag) {
+		t.Fatalf("expected GenDocs to fail with ErrUnknownFlag, got %v", err)
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	got, err := splitCommandLine(`mycli a\ b "c \"d\"" 'e $f' g#h ; i`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"mycli", "a b", `c "d"`, "e $f", "g#h"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
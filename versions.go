@@ -0,0 +1,125 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Command and flag annotations documenting the history of a command or flag.
+// A version may be followed by a colon and a note, for example "1.2.0: Added JSON output.".
+// The VersionChangedAnnotation of a command holds one change per line, the one of a flag one change per value.
+// The VersionAddedAnnotation and DeprecatedSinceAnnotation of a flag hold a single value, only the first is used.
+const (
+	VersionAddedAnnotation    = "versionAdded"
+	VersionChangedAnnotation  = "versionChanged"
+	DeprecatedSinceAnnotation = "deprecatedSince"
+)
+
+// versionDirective returns the directive for a version annotation value, escaping its note.
+func versionDirective(s *sanitizer, field, directive, value string) string {
+	version, note, _ := strings.Cut(value, ":")
+	text := ".. " + directive + ":: " + strings.TrimSpace(version) + "\n"
+	if note = strings.TrimSpace(note); note != "" {
+		text += "\n" + indentString(s.escape(field+" "+directive+" note", note), "   ") + "\n"
+	}
+	return text
+}
+
+// versionDirectives returns the versionadded, versionchanged and deprecated directives
+// separated by blank lines.
+func versionDirectives(s *sanitizer, field, added string, changed []string, deprecatedSince string) string {
+	var directives []string
+	if added = strings.TrimSpace(added); added != "" {
+		directives = append(directives, versionDirective(s, field, "versionadded", added))
+	}
+	for _, change := range changed {
+		if strings.TrimSpace(change) != "" {
+			directives = append(directives, versionDirective(s, field, "versionchanged", change))
+		}
+	}
+	if deprecatedSince = strings.TrimSpace(deprecatedSince); deprecatedSince != "" {
+		directives = append(directives, versionDirective(s, field, "deprecated", deprecatedSince))
+	}
+	return strings.Join(directives, "\n")
+}
+
+func printVersions(buf *bytes.Buffer, cmd *cobra.Command, options *GenDocsOptions) {
+	directives := versionDirectives(
+		options.sanitizer,
+		cmd.CommandPath()+" command",
+		cmd.Annotations[VersionAddedAnnotation],
+		strings.Split(cmd.Annotations[VersionChangedAnnotation], "\n"),
+		cmd.Annotations[DeprecatedSinceAnnotation],
+	)
+	if directives != "" {
+		buf.WriteString("\n" + directives)
+	}
+}
+
+// flagVersions returns the version directives of flag, preceded by a blank line, to be appended to its description.
+func flagVersions(flag *pflag.Flag, s *sanitizer) string {
+	directives := versionDirectives(
+		s,
+		flag.Name+" flag",
+		firstAnnotation(flag, VersionAddedAnnotation),
+		flag.Annotations[VersionChangedAnnotation],
+		firstAnnotation(flag, DeprecatedSinceAnnotation),
+	)
+	if directives == "" {
+		return ""
+	}
+	return "\n\n" + strings.TrimSuffix(directives, "\n")
+}
+
+// firstAnnotation returns the first value of the annotation of flag, or "".
+func firstAnnotation(flag *pflag.Flag, key string) string {
+	if values := flag.Annotations[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// AddedIn lists the documented commands and visible flags of root and its subcommands whose
+// VersionAddedAnnotation is version, as CommandAdded and FlagAdded changes to be rendered
+// with ChangesToRST or ChangesToMarkdown.
+func AddedIn(root *cobra.Command, version string) []Change {
+	var changes []Change
+	for _, c := range NewSpec(root).Commands {
+		cmd, _, err := root.Find(strings.Fields(c.Path)[1:])
+		if err != nil {
+			continue
+		}
+		if addedIn(cmd.Annotations[VersionAddedAnnotation], version) {
+			changes = append(changes, Change{Kind: CommandAdded, Command: c.Path})
+		}
+		for _, f := range c.Flags {
+			flag := cmd.LocalFlags().Lookup(f.Name)
+			if flag != nil && addedIn(firstAnnotation(flag, VersionAddedAnnotation), version) {
+				changes = append(changes, Change{Kind: FlagAdded, Command: c.Path, Name: f.Name, Required: f.Required})
+			}
+		}
+	}
+	return changes
+}
+
+func addedIn(annotation, version string) bool {
+	added, _, _ := strings.Cut(annotation, ":")
+	return strings.TrimSpace(added) == version
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cobra2snooty
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func versionsTree() *cobra.Command {
+	root := &cobra.Command{Use: "mycli"}
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List clusters",
+		Run:   emptyRun,
+		Annotations: map[string]string{
+			VersionAddedAnnotation:    "1.2.0",
+			VersionChangedAnnotation:  "1.3.0: Added JSON output.\n1.4.0",
+			DeprecatedSinceAnnotation: "1.5.0",
+		},
+	}
+	list.Flags().String("output", "", "Output format.")
+	list.Flags().Int("limit", 0, "Number of results.")
+	_ = list.Flags().SetAnnotation("limit", VersionAddedAnnotation, []string{"1.3.0"})
+	_ = list.Flags().SetAnnotation("limit", VersionChangedAnnotation, []string{"1.4.0: Defaults to 0."})
+	describe := &cobra.Command{Use: "describe", Short: "Describe a cluster", Run: emptyRun}
+	describe.Flags().Bool("verbose", false, "Verbose output.")
+	_ = describe.Flags().SetAnnotation("verbose", VersionAddedAnnotation, []string{"1.2.0"})
+	root.AddCommand(list, describe)
+	return root
+}
+
+func TestGenDocsVersions(t *testing.T) {
+	root := versionsTree()
+	list, _, _ := root.Find([]string{"list"})
+	buf := new(bytes.Buffer)
+	if err := GenDocs(list, buf, WithoutDate()); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	checkStringContains(t, out, "List clusters\n\n.. versionadded:: 1.2.0\n\n"+
+		".. versionchanged:: 1.3.0\n\n   Added JSON output.\n\n"+
+		".. versionchanged:: 1.4.0\n\n"+
+		".. deprecated:: 1.5.0\n")
+	checkStringContains(t, out, "     - Number of results.\n\n"+
+		"       .. versionadded:: 1.3.0\n\n"+
+		"       .. versionchanged:: 1.4.0\n\n"+
+		"          Defaults to 0.\n")
+
+	buf.Reset()
+	if err := GenDocs(root, buf, WithoutDate()); err != nil {
+		t.Fatal(err)
+	}
+	checkStringOmits(t, buf.String(), ".. version")
+}
+
+func TestGenDocsVersionNotes(t *testing.T) {
+	root := &cobra.Command{Use: "mycli"}
+	list := &cobra.Command{
+		Use:         "list",
+		Short:       "List clusters",
+		Run:         emptyRun,
+		Annotations: map[string]string{VersionChangedAnnotation: "1.3.0: Supports *globs*."},
+	}
+	list.Flags().Int("limit", 0, "Number of results.")
+	_ = list.Flags().SetAnnotation("limit", VersionAddedAnnotation, []string{"1.3.0: Requires `--all`.", "1.4.0"})
+	root.AddCommand(list)
+
+	buf := new(bytes.Buffer)
+	if err := GenDocs(list, buf, WithoutDate(), WithEscaping()); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	checkStringContains(t, out, ".. versionchanged:: 1.3.0\n\n   Supports \\*globs\\*.\n")
+	// only the first value of the versionAdded annotation of a flag is used
+	checkStringContains(t, out, "       .. versionadded:: 1.3.0\n\n          Requires \\`--all\\`.\n")
+	checkStringOmits(t, out, "1.4.0")
+	if got := AddedIn(root, "1.3.0"); len(got) != 1 || got[0].Name != "limit" {
+		t.Errorf("expected the limit flag, got %v", got)
+	}
+}
+
+func TestAddedIn(t *testing.T) {
+	root := versionsTree()
+	expected := []Change{
+		{Kind: FlagAdded, Command: "mycli describe", Name: "verbose"},
+		{Kind: CommandAdded, Command: "mycli list"},
+	}
+	if got := AddedIn(root, "1.2.0"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	expected = []Change{{Kind: FlagAdded, Command: "mycli list", Name: "limit"}}
+	if got := AddedIn(root, "1.3.0"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := AddedIn(root, "2.0.0"); len(got) != 0 {
+		t.Errorf("expected no changes, got %v", got)
+	}
+}